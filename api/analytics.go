@@ -0,0 +1,80 @@
+package findfreetimes
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Count is how many searches share a key, a room or a weekday for instance.
+type Count struct {
+	Key      string `json:"key"`
+	Searches int    `json:"searches"`
+}
+
+// Usage aggregates the search history of a period.
+type Usage struct {
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	Searches     int       `json:"searches"`
+	Rooms        []Count   `json:"rooms"`        // most requested rooms
+	Weekdays     []Count   `json:"weekdays"`     // most requested weekdays
+	TimeRanges   []Count   `json:"timeRanges"`   // most requested "start-end" windows
+	NothingFound []Count   `json:"nothingFound"` // weekday and window of searches without results
+	PeakHours    []Count   `json:"peakHours"`    // hour of the day searches were made at, on the campus clock
+}
+
+// Analyze aggregates the searches made between from and to.
+func Analyze(from time.Time, to time.Time) Usage {
+	entries, _ := QueryHistory(HistoryFilter{From: from, To: to})
+
+	rooms := map[string]int{}
+	weekdays := map[string]int{}
+	timeRanges := map[string]int{}
+	nothingFound := map[string]int{}
+	peakHours := map[string]int{}
+
+	for _, entry := range entries {
+		window := entry.StartTime + "-" + entry.EndTime
+
+		for _, room := range entry.Rooms {
+			rooms[room]++
+		}
+		weekdays[entry.Weekday]++
+		timeRanges[window]++
+		peakHours[strconv.Itoa(entry.Time.In(Campus).Hour())+":00"]++
+
+		if entry.Results == 0 {
+			nothingFound[entry.Weekday+" "+window]++
+		}
+	}
+
+	return Usage{
+		From:         from,
+		To:           to,
+		Searches:     len(entries),
+		Rooms:        ranked(rooms),
+		Weekdays:     ranked(weekdays),
+		TimeRanges:   ranked(timeRanges),
+		NothingFound: ranked(nothingFound),
+		PeakHours:    ranked(peakHours),
+	}
+}
+
+// ranked sorts counts from the most to the least searched.
+func ranked(counts map[string]int) []Count {
+	result := make([]Count, 0, len(counts))
+
+	for key, searches := range counts {
+		result = append(result, Count{key, searches})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Searches != result[j].Searches {
+			return result[i].Searches > result[j].Searches
+		}
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
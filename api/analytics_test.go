@@ -0,0 +1,44 @@
+package findfreetimes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnalyze(t *testing.T) {
+	stubHistory(t)
+	saved := HistoryRetention
+	HistoryRetention = 0
+	defer func() { HistoryRetention = saved }()
+
+	summer := time.Date(2018, time.June, 4, 8, 30, 0, 0, time.UTC) // 9:30 in Dublin
+	winter := time.Date(2018, time.January, 8, 8, 30, 0, 0, time.UTC)
+
+	RecordSearch(HistoryEntry{Time: summer, Weekday: "monday", StartTime: "9:15", EndTime: "10:15", Rooms: []string{"IT101", "IT102"}, Results: 2})
+	RecordSearch(HistoryEntry{Time: summer.Add(time.Minute), Weekday: "monday", StartTime: "9:15", EndTime: "10:15", Rooms: []string{"IT101"}})
+	RecordSearch(HistoryEntry{Time: winter, Weekday: "friday", StartTime: "14:15", EndTime: "16:15", Rooms: []string{"IT101"}, Results: 1})
+
+	cases := []struct {
+		name   string
+		from   time.Time
+		to     time.Time
+		metric func(Usage) []Count
+		want   []Count
+	}{
+		{"ranks rooms", winter, summer.Add(time.Hour), func(u Usage) []Count { return u.Rooms }, []Count{{"IT101", 3}, {"IT102", 1}}},
+		{"ranks weekdays", winter, summer.Add(time.Hour), func(u Usage) []Count { return u.Weekdays }, []Count{{"monday", 2}, {"friday", 1}}},
+		{"ranks windows", winter, summer.Add(time.Hour), func(u Usage) []Count { return u.TimeRanges }, []Count{{"9:15-10:15", 2}, {"14:15-16:15", 1}}},
+		{"counts searches without results", winter, summer.Add(time.Hour), func(u Usage) []Count { return u.NothingFound }, []Count{{"monday 9:15-10:15", 1}}},
+		{"buckets peak hours on the campus clock", winter, summer.Add(time.Hour), func(u Usage) []Count { return u.PeakHours }, []Count{{"9:00", 2}, {"8:00", 1}}},
+		{"keeps to the period", summer, summer.Add(time.Hour), func(u Usage) []Count { return u.Weekdays }, []Count{{"monday", 2}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.metric(Analyze(c.from, c.to)); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
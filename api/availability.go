@@ -0,0 +1,102 @@
+package findfreetimes
+
+import (
+	"context"
+	s "strings"
+)
+
+// Availability tells whether a room is free in a slot, or what it is
+// booked for.
+type Availability struct {
+	Room    string `json:"room"`
+	Week    int    `json:"week"`
+	Weekday string `json:"weekday"`
+	Time    string `json:"time"`
+	Free    bool   `json:"free"`
+	Event   *Event `json:"event,omitempty"`
+}
+
+// AvailabilityFilter narrows down FindAvailability. Zero fields match
+// everything, except Week which defaults to DefaultWeek.
+type AvailabilityFilter struct {
+	Week      int
+	Days      []string
+	StartTime string
+	EndTime   string
+	Rooms     []string
+	Building  string
+	Free      *bool // only free slots when true, only booked ones when false
+}
+
+// FindAvailability lists the slots of the rooms matching f, room by room in
+// timetable order.
+func FindAvailability(f AvailabilityFilter) ([]Availability, Freshness, error) {
+	if f.Week == 0 {
+		f.Week = DefaultWeek
+	}
+	if !validWeek(f.Week) {
+		return nil, Freshness{}, ErrInvalidWeek
+	}
+
+	days := f.Days
+	if len(days) == 0 {
+		days = weekdays
+	}
+	for _, weekday := range days {
+		if _, dayErr := getRows(weekday); dayErr != nil {
+			return nil, Freshness{}, dayErr
+		}
+	}
+
+	if f.StartTime == "" && f.EndTime == "" {
+		f.StartTime, f.EndTime = supportedTimes[0], supportedTimes[len(supportedTimes)-1]
+	}
+	times, timesErr := getTimes(f.StartTime, f.EndTime)
+	if timesErr != nil {
+		return nil, Freshness{}, timesErr
+	}
+
+	roomsToFind := make([]string, 0)
+	for _, room := range rooms {
+		if (len(f.Rooms) == 0 || Contains(room, f.Rooms)) && (f.Building == "" || Building(room) == f.Building) {
+			roomsToFind = append(roomsToFind, room)
+		}
+	}
+
+	byRoom := map[string][]Availability{}
+	freshness, err := collect(context.Background(), roomsToFind, f.Week, func(tt *Timetable) {
+		slots := make([]Availability, 0)
+
+		for _, weekday := range days {
+			for _, slot := range tt.Days[weekday] {
+				if !Contains(slot.Time, times) || (f.Free != nil && slot.Free != *f.Free) {
+					continue
+				}
+				slots = append(slots, Availability{tt.Room, tt.Week, weekday, slot.Time, slot.Free, slot.Event})
+			}
+		}
+
+		byRoom[tt.Room] = slots
+	})
+
+	if err != nil {
+		return nil, freshness, err
+	}
+
+	availability := make([]Availability, 0)
+	for _, room := range roomsToFind {
+		availability = append(availability, byRoom[room]...)
+	}
+
+	return availability, freshness, nil
+}
+
+// Building is the building a room is in, the letters its code starts with
+// without the G of ground floor rooms, e.g. IT for both IT220 and ITG17.
+func Building(room string) string {
+	letters := s.TrimRight(room, "0123456789")
+	if len(letters) < len(room) && len(letters) > 1 && s.HasSuffix(letters, "G") {
+		letters = letters[:len(letters)-1]
+	}
+	return letters
+}
@@ -0,0 +1,130 @@
+package findfreetimes
+
+import (
+	"sort"
+	"strconv"
+	s "strings"
+)
+
+// every slot on the timetable lasts an hour
+var slotLength = 60
+
+// Block is a run of consecutive free slots in a room.
+type Block struct {
+	Room   string  `json:"room"`
+	Start  string  `json:"start"`
+	End    string  `json:"end"`
+	Length int     `json:"length"` // minutes
+	Fit    float64 `json:"fit"`    // share of the requested window the block covers
+}
+
+// Blocks splits the free times of a search into contiguous blocks, keeping
+// those lasting at least minDuration minutes. The blocks covering most of
+// the startTime to endTime window come first.
+func Blocks(result *Result, startTime string, endTime string, minDuration int) []Block {
+	blocks := make([]Block, 0)
+
+	times, err := getTimes(startTime, endTime)
+	if err != nil {
+		return blocks
+	}
+	window := len(times) * slotLength
+
+	for _, roomTimes := range result.Rooms {
+		for _, run := range runs(roomTimes.Times) {
+			length := len(run) * slotLength
+			if length < minDuration {
+				continue
+			}
+
+			blocks = append(blocks, Block{
+				Room:   roomTimes.Room,
+				Start:  run[0],
+				End:    slotEnd(run[len(run)-1]),
+				Length: length,
+				Fit:    float64(length) / float64(window),
+			})
+		}
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if blocks[i].Fit != blocks[j].Fit {
+			return blocks[i].Fit > blocks[j].Fit
+		}
+		return slotIndex(blocks[i].Start) < slotIndex(blocks[j].Start)
+	})
+
+	return blocks
+}
+
+// WithinBlocks cuts the free times of rooms down to those in one of the
+// blocks, leaving out the rooms that have none.
+func WithinBlocks(rooms []RoomTimes, blocks []Block) []RoomTimes {
+	within := make([]RoomTimes, 0)
+
+	for _, roomTimes := range rooms {
+		times := make([]string, 0)
+		for _, t := range roomTimes.Times {
+			if inBlock(roomTimes.Room, t, blocks) {
+				times = append(times, t)
+			}
+		}
+
+		if len(times) > 0 {
+			within = append(within, RoomTimes{roomTimes.Room, times})
+		}
+	}
+
+	return within
+}
+
+func inBlock(room string, time string, blocks []Block) bool {
+	i := slotIndex(time)
+	for _, block := range blocks {
+		start := slotIndex(block.Start)
+		if block.Room == room && i >= start && i < start+block.Length/slotLength {
+			return true
+		}
+	}
+	return false
+}
+
+// runs groups free times into runs of consecutive slots, in timetable order.
+func runs(times []string) [][]string {
+	indexes := make([]int, 0, len(times))
+	for _, t := range times {
+		if i := slotIndex(t); i >= 0 {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.Ints(indexes)
+
+	result := make([][]string, 0)
+	for n, i := range indexes {
+		if n == 0 || indexes[n-1] != i-1 {
+			result = append(result, []string{})
+		}
+		result[len(result)-1] = append(result[len(result)-1], supportedTimes[i])
+	}
+
+	return result
+}
+
+// slotIndex is the position of a time in supportedTimes, -1 if unsupported.
+func slotIndex(time string) int {
+	for i, t := range supportedTimes {
+		if t == time {
+			return i
+		}
+	}
+	return -1
+}
+
+// slotEnd is when the slot starting at time finishes.
+func slotEnd(time string) string {
+	parts := s.Split(time, ":")
+	hour, err := strconv.Atoi(parts[0])
+	check(err)
+
+	return strconv.Itoa(hour+slotLength/60) + ":" + parts[1]
+}
@@ -0,0 +1,67 @@
+package findfreetimes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBlocks(t *testing.T) {
+	result := &Result{Rooms: []RoomTimes{
+		{"IT101", []string{"9:15", "10:15", "12:15"}},
+		{"IT102", []string{"9:15", "10:15", "11:15", "12:15"}},
+	}}
+
+	cases := []struct {
+		name        string
+		startTime   string
+		endTime     string
+		minDuration int
+		want        []Block
+	}{
+		{"splits free times into runs, best fit first", "9:15", "12:15", 0, []Block{
+			{"IT102", "9:15", "13:15", 240, 1},
+			{"IT101", "9:15", "11:15", 120, 0.5},
+			{"IT101", "12:15", "13:15", 60, 0.25},
+		}},
+		{"drops blocks shorter than minDuration", "9:15", "12:15", 90, []Block{
+			{"IT102", "9:15", "13:15", 240, 1},
+			{"IT101", "9:15", "11:15", 120, 0.5},
+		}},
+		{"keeps nothing when no block is long enough", "9:15", "12:15", 300, []Block{}},
+		{"gives nothing for an invalid window", "12:15", "9:15", 0, []Block{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Blocks(result, c.startTime, c.endTime, c.minDuration); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestWithinBlocks(t *testing.T) {
+	rooms := []RoomTimes{
+		{"IT101", []string{"9:15", "10:15", "12:15"}},
+		{"IT102", []string{"12:15"}},
+	}
+
+	cases := []struct {
+		name   string
+		blocks []Block
+		want   []RoomTimes
+	}{
+		{"keeps the times in a block", []Block{{Room: "IT101", Start: "9:15", Length: 120}}, []RoomTimes{{"IT101", []string{"9:15", "10:15"}}}},
+		{"matches blocks to their room", []Block{{Room: "IT102", Start: "12:15", Length: 60}}, []RoomTimes{{"IT102", []string{"12:15"}}}},
+		{"keeps several blocks", []Block{{Room: "IT101", Start: "12:15", Length: 60}, {Room: "IT102", Start: "12:15", Length: 60}}, []RoomTimes{{"IT101", []string{"12:15"}}, {"IT102", []string{"12:15"}}}},
+		{"drops every room without blocks", nil, []RoomTimes{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := WithinBlocks(rooms, c.blocks); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
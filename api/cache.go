@@ -0,0 +1,170 @@
+package findfreetimes
+
+import (
+	ers "errors"
+	console "fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// CacheTTL is how long a fetched timetable counts as fresh. Older snapshots
+// are still served, flagged as stale, while a refresh runs in the background.
+var CacheTTL = 30 * time.Minute
+
+var ErrUpstreamUnavailable = ers.New("Timetables are unavailable, studentssp.wit.ie did not respond")
+
+var ErrUnknownRoom = ers.New("Unknown room")
+var ErrInvalidWeek = ers.New("Invalid week")
+
+// FailureTTL is how long a failed fetch is remembered. Until then a room
+// that has never been fetched fails straight away instead of every request
+// waiting out the retries again, and stale snapshots are not refreshed.
+var FailureTTL = time.Minute
+
+// Fetch gets a room's week from the college website for the cache. Tests
+// swap it for a stub.
+var Fetch = fetchTimetable
+
+// caps the number of curl processes running at once
+var curlSlots = make(chan struct{}, maxCmds)
+
+// versions start from the clock so that they are not reused after a restart
+var timetables = &timetableCache{entries: map[cacheKey]*cacheEntry{}, version: uint64(time.Now().UnixNano())}
+
+// Snapshot is the last known-good timetable of a room in a week. Version
+// changes whenever a refresh finds the timetable changed, which happened at
+// ModifiedAt, and never otherwise.
+type Snapshot struct {
+	Timetable  *Timetable
+	FetchedAt  time.Time
+	ModifiedAt time.Time
+	Version    uint64
+}
+
+func (sn *Snapshot) Age() time.Duration {
+	return time.Since(sn.FetchedAt)
+}
+
+func (sn *Snapshot) Stale() bool {
+	return sn.Age() > CacheTTL
+}
+
+type timetableCache struct {
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+	version uint64 // of the newest snapshot
+}
+
+type cacheKey struct {
+	room string
+	week int
+}
+
+type cacheEntry struct {
+	snapshot *Snapshot
+	// closed when the fetch in flight finishes, nil when there is none
+	refreshing chan struct{}
+	failedAt   time.Time // when the last fetch failed, zero once one succeeds
+}
+
+func (e *cacheEntry) failedRecently() bool {
+	return !e.failedAt.IsZero() && time.Since(e.failedAt) < FailureTTL
+}
+
+// get returns the cached snapshot of a room's week, kicking off a background refresh
+// when it is stale. Only when there is no snapshot yet does it wait for the
+// fetch, failing with ErrUpstreamUnavailable if that does not succeed or if
+// the last one failed less than FailureTTL ago.
+func (c *timetableCache) get(room string, week int) (*Snapshot, error) {
+	c.mu.Lock()
+
+	key := cacheKey{room, week}
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &cacheEntry{}
+		c.entries[key] = entry
+	}
+
+	if snapshot := entry.snapshot; snapshot != nil {
+		if snapshot.Stale() && entry.refreshing == nil && !entry.failedRecently() {
+			c.refresh(key, entry)
+		}
+		c.mu.Unlock()
+		return snapshot, nil
+	}
+
+	done := entry.refreshing
+	if done == nil {
+		if entry.failedRecently() {
+			c.mu.Unlock()
+			return nil, ErrUpstreamUnavailable
+		}
+		done = c.refresh(key, entry)
+	}
+	c.mu.Unlock()
+
+	<-done
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry.snapshot == nil {
+		return nil, ErrUpstreamUnavailable
+	}
+
+	return entry.snapshot, nil
+}
+
+// GetTimetable returns the last known-good timetable of a room in a week.
+func GetTimetable(room string, week int) (*Snapshot, error) {
+	if !Contains(room, rooms) {
+		return nil, ErrUnknownRoom
+	}
+
+	if !validWeek(week) {
+		return nil, ErrInvalidWeek
+	}
+
+	return timetables.get(room, week)
+}
+
+// ClearCache forgets every snapshot and failed fetch, so that the next read
+// of each room fetches it again. Versions carry on from where they were.
+func ClearCache() {
+	timetables.mu.Lock()
+	defer timetables.mu.Unlock()
+
+	timetables.entries = map[cacheKey]*cacheEntry{}
+}
+
+// refresh must be called with c.mu held.
+func (c *timetableCache) refresh(key cacheKey, entry *cacheEntry) chan struct{} {
+	done := make(chan struct{})
+	entry.refreshing = done
+
+	go func() {
+		tt, err := Fetch(key.room, key.week)
+
+		c.mu.Lock()
+		if err == nil {
+			entry.failedAt = time.Time{}
+			now := time.Now()
+			if previous := entry.snapshot; previous != nil && reflect.DeepEqual(previous.Timetable, tt) {
+				entry.snapshot = &Snapshot{tt, now, previous.ModifiedAt, previous.Version}
+			} else {
+				c.version++
+				entry.snapshot = &Snapshot{tt, now, now, c.version}
+			}
+		} else {
+			entry.failedAt = time.Now()
+			console.Println(key.room + ": keeping last snapshot, " + err.Error())
+		}
+		entry.refreshing = nil
+		c.mu.Unlock()
+
+		close(done)
+	}()
+
+	return done
+}
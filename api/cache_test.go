@@ -0,0 +1,225 @@
+package findfreetimes
+
+import (
+	ers "errors"
+	s "strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTimetable is a week of room with every slot free but the busy ones,
+// given as "weekday time".
+func newTimetable(room string, week int, busy ...string) *Timetable {
+	tt := &Timetable{Room: room, Week: week, Days: map[string][]Slot{}}
+
+	for _, weekday := range weekdays {
+		slots := make([]Slot, 0, len(supportedTimes))
+		for _, time := range supportedTimes {
+			slot := Slot{Time: time, Free: true}
+			if Contains(weekday+" "+time, busy) {
+				slot = Slot{Time: time, Event: &Event{Module: "M" + s.Replace(time, ":", "", 1)}}
+			}
+			slots = append(slots, slot)
+		}
+		tt.Days[weekday] = slots
+	}
+
+	return tt
+}
+
+// stubFetch empties the cache and answers its fetches with f for the rest
+// of the test, counting them.
+func stubFetch(t *testing.T, f func(room string, week int) (*Timetable, error)) *fetchCounter {
+	counter := &fetchCounter{}
+	saved := Fetch
+
+	ClearCache()
+	Fetch = func(room string, week int) (*Timetable, error) {
+		counter.mu.Lock()
+		counter.n++
+		counter.mu.Unlock()
+		return f(room, week)
+	}
+
+	t.Cleanup(func() { Fetch = saved })
+	return counter
+}
+
+// stubTimetables answers fetches with the given timetables, and fails for
+// the rooms that have none.
+func stubTimetables(t *testing.T, tts ...*Timetable) *fetchCounter {
+	return stubFetch(t, func(room string, week int) (*Timetable, error) {
+		for _, tt := range tts {
+			if tt.Room == room && tt.Week == week {
+				return tt, nil
+			}
+		}
+		return nil, ers.New(room + ": no response")
+	})
+}
+
+type fetchCounter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c *fetchCounter) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+// waitRefresh waits for the fetch in flight of a room's week, if any.
+func waitRefresh(room string, week int) {
+	timetables.mu.Lock()
+	entry := timetables.entries[cacheKey{room, week}]
+	var done chan struct{}
+	if entry != nil {
+		done = entry.refreshing
+	}
+	timetables.mu.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+func TestGetTimetable(t *testing.T) {
+	cases := []struct {
+		name    string
+		room    string
+		week    int
+		fetched *Timetable
+		want    error
+	}{
+		{"fetches a cold room", "IT101", 10, newTimetable("IT101", 10), nil},
+		{"fails when the fetch does", "IT101", 10, nil, ErrUpstreamUnavailable},
+		{"rejects unknown rooms", "XX999", 10, nil, ErrUnknownRoom},
+		{"rejects weeks out of range", "IT101", 99, nil, ErrInvalidWeek},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.fetched != nil {
+				stubTimetables(t, c.fetched)
+			} else {
+				stubTimetables(t)
+			}
+
+			snapshot, err := GetTimetable(c.room, c.week)
+			if err != c.want {
+				t.Fatalf("err = %v, want %v", err, c.want)
+			}
+			if err == nil && snapshot.Timetable != c.fetched {
+				t.Errorf("got timetable %+v, want %+v", snapshot.Timetable, c.fetched)
+			}
+		})
+	}
+}
+
+func TestGetTimetableRemembersFailures(t *testing.T) {
+	fetches := stubTimetables(t)
+
+	for i := 0; i < 3; i++ {
+		if _, err := GetTimetable("IT101", 10); err != ErrUpstreamUnavailable {
+			t.Fatalf("attempt %d: err = %v, want ErrUpstreamUnavailable", i, err)
+		}
+	}
+	if n := fetches.count(); n != 1 {
+		t.Errorf("fetched %d times within FailureTTL, want 1", n)
+	}
+
+	saved := FailureTTL
+	FailureTTL = 0
+	defer func() { FailureTTL = saved }()
+
+	GetTimetable("IT101", 10)
+	if n := fetches.count(); n != 2 {
+		t.Errorf("fetched %d times after FailureTTL, want 2", n)
+	}
+}
+
+func TestGetTimetableServesStaleSnapshots(t *testing.T) {
+	first := newTimetable("IT101", 10)
+	fail := false
+	stubFetch(t, func(room string, week int) (*Timetable, error) {
+		if fail {
+			return nil, ErrUpstreamUnavailable
+		}
+		return first, nil
+	})
+
+	if _, err := GetTimetable("IT101", 10); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := CacheTTL
+	CacheTTL = -time.Second
+	defer func() { CacheTTL = saved }()
+	fail = true
+
+	snapshot, err := GetTimetable("IT101", 10)
+	if err != nil {
+		t.Fatalf("stale read failed: %v", err)
+	}
+	if snapshot.Timetable != first || !snapshot.Stale() {
+		t.Errorf("got %+v, want the first timetable flagged stale", snapshot)
+	}
+
+	waitRefresh("IT101", 10)
+
+	snapshot, err = GetTimetable("IT101", 10)
+	if err != nil || snapshot.Timetable != first {
+		t.Errorf("after a failed refresh got %+v, %v, want the last snapshot", snapshot, err)
+	}
+}
+
+func TestSnapshotVersions(t *testing.T) {
+	cases := []struct {
+		name     string
+		refetch  *Timetable
+		clear    bool
+		sameVer  bool
+		sameTime bool
+	}{
+		{"keeps the version of an unchanged timetable", newTimetable("IT101", 10), false, true, true},
+		{"bumps the version of a changed timetable", newTimetable("IT101", 10, "monday 9:15"), false, false, false},
+		{"carries versions on over a cleared cache", newTimetable("IT101", 10, "monday 9:15"), true, false, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fetched := newTimetable("IT101", 10)
+			stubFetch(t, func(room string, week int) (*Timetable, error) { return fetched, nil })
+
+			first, err := GetTimetable("IT101", 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			saved := CacheTTL
+			CacheTTL = -time.Second
+			defer func() { CacheTTL = saved }()
+			fetched = c.refetch
+			if c.clear {
+				ClearCache()
+			}
+
+			GetTimetable("IT101", 10)
+			waitRefresh("IT101", 10)
+			second, err := GetTimetable("IT101", 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			waitRefresh("IT101", 10)
+
+			if sameVer := second.Version == first.Version; sameVer != c.sameVer || second.Version < first.Version {
+				t.Errorf("version %d after %d, want the same: %v", second.Version, first.Version, c.sameVer)
+			}
+			if sameTime := second.ModifiedAt.Equal(first.ModifiedAt); sameTime != c.sameTime {
+				t.Errorf("modified %v after %v, want the same: %v", second.ModifiedAt, first.ModifiedAt, c.sameTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package findfreetimes
+
+import "sort"
+
+// CommonSlot is a time at which several of the searched rooms are free at once.
+type CommonSlot struct {
+	Time  string   `json:"time"`
+	Rooms []string `json:"rooms"`
+}
+
+// Common returns the times between startTime and endTime at which at least
+// minRooms of the searched rooms are free, in timetable order.
+func Common(result *Result, startTime string, endTime string, minRooms int) []CommonSlot {
+	common := make([]CommonSlot, 0)
+
+	times, err := getTimes(startTime, endTime)
+	if err != nil {
+		return common
+	}
+
+	for _, t := range times {
+		slot := CommonSlot{t, make([]string, 0)}
+
+		for _, roomTimes := range result.Rooms {
+			if Contains(t, roomTimes.Times) {
+				slot.Rooms = append(slot.Rooms, roomTimes.Room)
+			}
+		}
+
+		if len(slot.Rooms) >= minRooms {
+			sort.Strings(slot.Rooms)
+			common = append(common, slot)
+		}
+	}
+
+	return common
+}
@@ -0,0 +1,36 @@
+package findfreetimes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommon(t *testing.T) {
+	result := &Result{Rooms: []RoomTimes{
+		{"IT102", []string{"9:15", "10:15"}},
+		{"IT101", []string{"9:15", "11:15"}},
+		{"IT103", []string{"9:15", "10:15", "11:15"}},
+	}}
+
+	cases := []struct {
+		name     string
+		minRooms int
+		want     []CommonSlot
+	}{
+		{"finds the times all rooms are free", 3, []CommonSlot{{"9:15", []string{"IT101", "IT102", "IT103"}}}},
+		{"finds the times enough rooms are free", 2, []CommonSlot{
+			{"9:15", []string{"IT101", "IT102", "IT103"}},
+			{"10:15", []string{"IT102", "IT103"}},
+			{"11:15", []string{"IT101", "IT103"}},
+		}},
+		{"finds nothing when too many rooms are asked for", 4, []CommonSlot{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Common(result, "9:15", "11:15", c.minRooms); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
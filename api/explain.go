@@ -0,0 +1,69 @@
+package findfreetimes
+
+// Why a room was or was not in the results of a search.
+const (
+	StatusFree        = "free"
+	StatusBusy        = "busy"
+	StatusUnavailable = "unavailable"         // the timetable could not be fetched
+	StatusUnknownRoom = "not-a-teaching-room" // the room is not in the catalog
+)
+
+// Explanation tells, slot by slot, why a room did or did not come up.
+type Explanation struct {
+	Room   string       `json:"room"`
+	Status string       `json:"status,omitempty"` // set when the room has no timetable to explain with
+	Slots  []SlotStatus `json:"slots,omitempty"`
+}
+
+type SlotStatus struct {
+	Time   string `json:"time"`
+	Status string `json:"status"`
+	Event  *Event `json:"event,omitempty"` // what the room is booked for
+}
+
+// Explain goes through every room of a search and the slots between
+// startTime and endTime on weekday, telling which were free and what the
+// others are booked for. Rooms asked for twice are explained once.
+func Explain(result *Result, weekday string, startTime string, endTime string, roomsToFind []string) []Explanation {
+	explanations := make([]Explanation, 0, len(roomsToFind))
+
+	times, err := getTimes(startTime, endTime)
+	if err != nil {
+		return explanations
+	}
+
+	explained := make([]string, 0, len(roomsToFind))
+
+	for _, room := range roomsToFind {
+		if Contains(room, explained) {
+			continue
+		}
+		explained = append(explained, room)
+
+		explanation := Explanation{Room: room}
+		tt, ok := result.timetables[room]
+
+		switch {
+		case Contains(room, result.Unknown):
+			explanation.Status = StatusUnknownRoom
+		case !ok:
+			explanation.Status = StatusUnavailable
+		default:
+			for _, slot := range tt.Days[weekday] {
+				if !Contains(slot.Time, times) {
+					continue
+				}
+
+				if slot.Free {
+					explanation.Slots = append(explanation.Slots, SlotStatus{slot.Time, StatusFree, nil})
+				} else {
+					explanation.Slots = append(explanation.Slots, SlotStatus{slot.Time, StatusBusy, slot.Event})
+				}
+			}
+		}
+
+		explanations = append(explanations, explanation)
+	}
+
+	return explanations
+}
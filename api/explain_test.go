@@ -0,0 +1,43 @@
+package findfreetimes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", 10, "monday 10:15"))
+
+	busy := &Event{Module: "M1015"}
+
+	cases := []struct {
+		name  string
+		rooms []string
+		want  []Explanation
+	}{
+		{"tells free and busy slots", []string{"IT101"}, []Explanation{
+			{Room: "IT101", Slots: []SlotStatus{{"9:15", StatusFree, nil}, {"10:15", StatusBusy, busy}}},
+		}},
+		{"tells rooms outside the catalog", []string{"XX999"}, []Explanation{{Room: "XX999", Status: StatusUnknownRoom}}},
+		{"tells rooms that could not be fetched", []string{"IT101", "IT102"}, []Explanation{
+			{Room: "IT101", Slots: []SlotStatus{{"9:15", StatusFree, nil}, {"10:15", StatusBusy, busy}}},
+			{Room: "IT102", Status: StatusUnavailable},
+		}},
+		{"explains repeated rooms once", []string{"XX999", "IT101", "XX999"}, []Explanation{
+			{Room: "XX999", Status: StatusUnknownRoom},
+			{Room: "IT101", Slots: []SlotStatus{{"9:15", StatusFree, nil}, {"10:15", StatusBusy, busy}}},
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := FindEach(10, "monday", "9:15", "10:15", append(c.rooms, "IT101"), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Explain(result, "monday", "9:15", "10:15", c.rooms); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
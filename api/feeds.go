@@ -0,0 +1,394 @@
+package findfreetimes
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	ers "errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	s "strings"
+	"sync"
+	"time"
+)
+
+// MaxFeedsPerUser caps how many feed tokens one user can hold at once.
+var MaxFeedsPerUser = 20
+
+// FeedSecret signs feed tokens. Tokens stay valid across restarts as long
+// as it does not change, and changing it revokes every feed at once. It is
+// random unless set, so by default feeds last until the server restarts.
+var FeedSecret = randomSecret()
+
+// FeedsFile keeps the feeds issued and revoked across restarts, so that a
+// revoked token is not served again. They are only kept in memory when it
+// is empty.
+var FeedsFile = ""
+
+var ErrTooManyFeeds = ers.New("Revoke a feed before creating another one")
+var ErrFeedsNotSaved = ers.New("Feeds could not be saved, try again")
+
+// Feed is a calendar subscription. Its token stands in for the bearer
+// token calendar apps cannot send, so it is only ever shown to its owner.
+type Feed struct {
+	ID      int64     `json:"id"`
+	Token   string    `json:"token"`
+	User    string    `json:"-"` // JWT subject of the owner
+	Rooms   []string  `json:"rooms"`
+	Created time.Time `json:"created"`
+}
+
+// feedClaims is what a token carries, so that it can be served without a
+// lookup.
+type feedClaims struct {
+	ID      int64    `json:"i"`
+	Rooms   []string `json:"r"`
+	Created int64    `json:"c"` // Unix nanoseconds
+}
+
+// feedStore knows the feeds issued and revoked, since the server started
+// or as FeedsFile kept them. Tokens do not need it to be served, only to be
+// listed and revoked.
+type feedStore struct {
+	mu      sync.RWMutex
+	byID    map[int64]Feed
+	revoked map[int64]bool
+}
+
+var feeds = &feedStore{byID: make(map[int64]Feed), revoked: make(map[int64]bool)}
+
+// feedsSaved is the content of FeedsFile. Feed leaves its owner out of JSON
+// answers, so storedFeed adds it back.
+type feedsSaved struct {
+	Feeds   []storedFeed `json:"feeds"`
+	Revoked []int64      `json:"revoked"`
+}
+
+type storedFeed struct {
+	Feed
+	User string `json:"user"`
+}
+
+// LoadFeeds reads the feeds and revocations kept in FeedsFile, if any.
+func LoadFeeds() error {
+	if FeedsFile == "" {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(FeedsFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var saved feedsSaved
+	if err := json.Unmarshal(content, &saved); err != nil {
+		return err
+	}
+
+	feeds.mu.Lock()
+	defer feeds.mu.Unlock()
+
+	for _, stored := range saved.Feeds {
+		stored.Feed.User = stored.User
+		feeds.byID[stored.ID] = stored.Feed
+	}
+	for _, id := range saved.Revoked {
+		feeds.revoked[id] = true
+	}
+
+	return nil
+}
+
+// IssueFeed creates a feed of rooms for user, with a token signed by
+// FeedSecret.
+func IssueFeed(user string, rooms []string) (Feed, error) {
+	id, err := feedID()
+	if err != nil {
+		return Feed{}, err
+	}
+
+	feed := Feed{
+		ID:      id,
+		User:    user,
+		Rooms:   rooms,
+		Created: time.Now().Round(0),
+	}
+	if feed.Token, err = signFeed(feed); err != nil {
+		return Feed{}, err
+	}
+
+	feeds.mu.Lock()
+	defer feeds.mu.Unlock()
+
+	if MaxFeedsPerUser > 0 && len(feeds.of(user)) >= MaxFeedsPerUser {
+		return Feed{}, ErrTooManyFeeds
+	}
+	feeds.byID[id] = feed
+
+	if err := feeds.save(); err != nil {
+		delete(feeds.byID, id)
+		return Feed{}, ErrFeedsNotSaved
+	}
+
+	return feed, nil
+}
+
+// UserFeeds lists the feeds user was issued, since the server started or
+// as FeedsFile kept them, oldest first.
+func UserFeeds(user string) []Feed {
+	feeds.mu.RLock()
+	defer feeds.mu.RUnlock()
+
+	return feeds.of(user)
+}
+
+// GetFeed reads the feed out of token, provided FeedSecret signed it and
+// it was not revoked. The owner is only known for feeds issued since the
+// server started or kept in FeedsFile.
+func GetFeed(token string) (Feed, bool) {
+	feed, ok := verifyFeed(token)
+	if !ok {
+		return Feed{}, false
+	}
+
+	feeds.mu.RLock()
+	defer feeds.mu.RUnlock()
+
+	if feeds.revoked[feed.ID] {
+		return Feed{}, false
+	}
+	if issued, ok := feeds.byID[feed.ID]; ok {
+		feed.User = issued.User
+	}
+
+	return feed, true
+}
+
+// RevokeFeed deletes the feed id, provided user owns it. The feed stays as
+// it was when the revocation could not be saved.
+func RevokeFeed(user string, id int64) (bool, error) {
+	feeds.mu.Lock()
+	defer feeds.mu.Unlock()
+
+	feed, ok := feeds.byID[id]
+	if !ok || feed.User != user {
+		return false, nil
+	}
+
+	delete(feeds.byID, id)
+	feeds.revoked[id] = true
+
+	if err := feeds.save(); err != nil {
+		feeds.byID[id] = feed
+		delete(feeds.revoked, id)
+		return false, ErrFeedsNotSaved
+	}
+
+	return true, nil
+}
+
+// FeedEvents is the free time of rooms in the given weeks, read through
+// the timetable cache. Every room and week is fetched at once, as in
+// FindContext, and rooms that cannot be fetched are left out. It fails with
+// ErrUpstreamUnavailable when no room could be read in any week.
+func FeedEvents(rooms []string, weeks []int) ([]CalendarEvent, Freshness, error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	events := make([]CalendarEvent, 0)
+	freshness := Freshness{}
+	read := false
+
+	for _, week := range weeks {
+		wg.Add(1)
+		go func(week int) {
+			defer wg.Done()
+
+			var found []CalendarEvent
+			weekFreshness, err := collect(context.Background(), rooms, week, func(tt *Timetable) {
+				found = append(found, freeTimetableEvents(tt)...)
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, found...)
+			freshness.merge(weekFreshness)
+			read = read || err == nil
+		}(week)
+	}
+	wg.Wait()
+
+	if !read {
+		return nil, Freshness{}, ErrUpstreamUnavailable
+	}
+
+	// rooms arrive in the order they are fetched
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Start.Equal(events[j].Start) {
+			return events[i].Start.Before(events[j].Start)
+		}
+		return events[i].UID < events[j].UID
+	})
+
+	return events, freshness, nil
+}
+
+func freeTimetableEvents(tt *Timetable) []CalendarEvent {
+	events := make([]CalendarEvent, 0)
+
+	for _, weekday := range weekdays {
+		blocks := make([]Block, 0)
+		for _, run := range runs(tt.freeTimes(weekday, supportedTimes)) {
+			blocks = append(blocks, Block{Room: tt.Room, Start: run[0], End: slotEnd(run[len(run)-1])})
+		}
+		events = append(events, FreeEvents(blocks, weekday, tt.Week)...)
+	}
+
+	return events
+}
+
+// merge folds the freshness of another batch of timetables into f.
+func (f *Freshness) merge(other Freshness) {
+	f.Unavailable = append(f.Unavailable, other.Unavailable...)
+	f.Stale = f.Stale || other.Stale
+	if other.Age > f.Age {
+		f.Age = other.Age
+	}
+	if other.Version > f.Version {
+		f.Version = other.Version
+	}
+	if other.Modified.After(f.Modified) {
+		f.Modified = other.Modified
+	}
+}
+
+// of must be called with feeds.mu held.
+func (f *feedStore) of(user string) []Feed {
+	result := make([]Feed, 0)
+	for _, feed := range f.byID {
+		if feed.User == user {
+			result = append(result, feed)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Created.Equal(result[j].Created) {
+			return result[i].Created.Before(result[j].Created)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// save writes the store to FeedsFile, through a temporary file so that a
+// failed write leaves the previous content. It must be called with feeds.mu
+// held.
+func (f *feedStore) save() error {
+	if FeedsFile == "" {
+		return nil
+	}
+
+	saved := feedsSaved{Feeds: make([]storedFeed, 0, len(f.byID)), Revoked: make([]int64, 0, len(f.revoked))}
+	for _, feed := range f.byID {
+		saved.Feeds = append(saved.Feeds, storedFeed{feed, feed.User})
+	}
+	for id := range f.revoked {
+		saved.Revoked = append(saved.Revoked, id)
+	}
+	sort.Slice(saved.Feeds, func(i, j int) bool { return saved.Feeds[i].ID < saved.Feeds[j].ID })
+	sort.Slice(saved.Revoked, func(i, j int) bool { return saved.Revoked[i] < saved.Revoked[j] })
+
+	content, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(FeedsFile), filepath.Base(FeedsFile)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), FeedsFile)
+}
+
+// signFeed encodes the feed as its claims followed by their HMAC.
+func signFeed(feed Feed) (string, error) {
+	payload, err := json.Marshal(feedClaims{feed.ID, feed.Rooms, feed.Created.UnixNano()})
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(feedMAC(payload)), nil
+}
+
+func verifyFeed(token string) (Feed, bool) {
+	parts := s.Split(token, ".")
+	if len(parts) != 2 {
+		return Feed{}, false
+	}
+
+	encoding := base64.RawURLEncoding
+	payload, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return Feed{}, false
+	}
+	mac, err := encoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(mac, feedMAC(payload)) {
+		return Feed{}, false
+	}
+
+	var claims feedClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Feed{}, false
+	}
+
+	return Feed{
+		ID:      claims.ID,
+		Token:   token,
+		Rooms:   claims.Rooms,
+		Created: time.Unix(0, claims.Created),
+	}, true
+}
+
+func feedMAC(payload []byte) []byte {
+	mac := hmac.New(sha256.New, FeedSecret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// feedID is random rather than counted, so that feeds issued before a
+// restart do not share IDs with the ones issued after it. It is at most
+// 2^53, which JavaScript clients still read exactly.
+func feedID() (int64, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b)>>11) + 1, nil
+}
+
+func randomSecret() []byte {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
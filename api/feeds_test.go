@@ -0,0 +1,196 @@
+package findfreetimes
+
+import (
+	ers "errors"
+	"path/filepath"
+	"reflect"
+	s "strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubFeeds empties the feed store, keeps it in a file of its own and signs
+// with secret for the rest of the test.
+func stubFeeds(t *testing.T, secret string) {
+	savedFeeds, savedSecret, savedFile := feeds, FeedSecret, FeedsFile
+
+	feeds = &feedStore{byID: make(map[int64]Feed), revoked: make(map[int64]bool)}
+	FeedSecret = []byte(secret)
+	FeedsFile = filepath.Join(t.TempDir(), "feeds.json")
+
+	t.Cleanup(func() { feeds, FeedSecret, FeedsFile = savedFeeds, savedSecret, savedFile })
+}
+
+// restart forgets the feeds in memory and reads FeedsFile again, as a
+// restart of the server does.
+func restart(t *testing.T) {
+	feeds = &feedStore{byID: make(map[int64]Feed), revoked: make(map[int64]bool)}
+	if err := LoadFeeds(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetFeed(t *testing.T) {
+	cases := []struct {
+		name   string
+		token  func(feed Feed) string
+		before func(t *testing.T, feed Feed)
+		want   bool
+	}{
+		{"serves issued feeds", func(feed Feed) string { return feed.Token }, nil, true},
+		{"serves feeds issued before a restart", func(feed Feed) string { return feed.Token }, func(t *testing.T, _ Feed) { restart(t) }, true},
+		{"rejects revoked feeds", func(feed Feed) string { return feed.Token }, func(_ *testing.T, feed Feed) { RevokeFeed("alice", feed.ID) }, false},
+		{"rejects feeds revoked before a restart", func(feed Feed) string { return feed.Token }, func(t *testing.T, feed Feed) {
+			RevokeFeed("alice", feed.ID)
+			restart(t)
+		}, false},
+		{"rejects feeds signed with another secret", func(feed Feed) string { return feed.Token }, func(*testing.T, Feed) { FeedSecret = []byte("rotated") }, false},
+		{"rejects tampered rooms", func(feed Feed) string {
+			forged, _ := signFeed(Feed{ID: feed.ID, Rooms: []string{"IT102"}, Created: feed.Created})
+			return s.Split(forged, ".")[0] + "." + s.Split(feed.Token, ".")[1]
+		}, nil, false},
+		{"rejects malformed tokens", func(Feed) string { return "not-a-token" }, nil, false},
+		{"rejects empty tokens", func(Feed) string { return "" }, nil, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stubFeeds(t, "secret")
+
+			feed, err := IssueFeed("alice", []string{"IT101"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.before != nil {
+				c.before(t, feed)
+			}
+
+			got, ok := GetFeed(c.token(feed))
+			if ok != c.want {
+				t.Fatalf("served = %v, want %v", ok, c.want)
+			}
+			if ok && (got.ID != feed.ID || !reflect.DeepEqual(got.Rooms, feed.Rooms) || !got.Created.Equal(feed.Created)) {
+				t.Errorf("got %+v, want %+v", got, feed)
+			}
+		})
+	}
+}
+
+func TestUserFeeds(t *testing.T) {
+	stubFeeds(t, "secret")
+
+	saved := MaxFeedsPerUser
+	MaxFeedsPerUser = 2
+	defer func() { MaxFeedsPerUser = saved }()
+
+	first, _ := IssueFeed("alice", []string{"IT101"})
+	second, _ := IssueFeed("alice", []string{"IT102"})
+	IssueFeed("bob", []string{"IT101"})
+
+	if _, err := IssueFeed("alice", []string{"IT103"}); err != ErrTooManyFeeds {
+		t.Errorf("third feed: err = %v, want ErrTooManyFeeds", err)
+	}
+
+	if got := UserFeeds("alice"); len(got) != 2 || got[0].ID != first.ID || got[1].ID != second.ID {
+		t.Errorf("got %+v, want the two feeds of alice", got)
+	}
+
+	if revoked, _ := RevokeFeed("bob", first.ID); revoked {
+		t.Error("bob revoked a feed of alice")
+	}
+	if revoked, err := RevokeFeed("alice", first.ID); !revoked || err != nil {
+		t.Errorf("alice could not revoke her feed: %v", err)
+	}
+	if got := UserFeeds("alice"); len(got) != 1 || got[0].ID != second.ID {
+		t.Errorf("after revoking got %+v, want the second feed only", got)
+	}
+
+	restart(t)
+	if got := UserFeeds("alice"); len(got) != 1 || got[0].ID != second.ID || got[0].User != "alice" {
+		t.Errorf("after a restart got %+v, want the second feed only", got)
+	}
+}
+
+func TestFeedsNotSaved(t *testing.T) {
+	stubFeeds(t, "secret")
+
+	feed, err := IssueFeed("alice", []string{"IT101"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	FeedsFile = filepath.Join(t.TempDir(), "missing", "feeds.json")
+
+	if _, err := IssueFeed("alice", []string{"IT102"}); err != ErrFeedsNotSaved {
+		t.Errorf("issuing: err = %v, want ErrFeedsNotSaved", err)
+	}
+	if revoked, err := RevokeFeed("alice", feed.ID); revoked || err != ErrFeedsNotSaved {
+		t.Errorf("revoking: got %v, %v, want ErrFeedsNotSaved", revoked, err)
+	}
+	if got := UserFeeds("alice"); len(got) != 1 || got[0].ID != feed.ID {
+		t.Errorf("got %+v, want the first feed only", got)
+	}
+	if _, ok := GetFeed(feed.Token); !ok {
+		t.Error("feed not served once its revocation failed")
+	}
+}
+
+func TestFeedEvents(t *testing.T) {
+	tts := []*Timetable{
+		newTimetable("IT101", 10, "monday 10:15"),
+		newTimetable("IT101", 11),
+		newTimetable("IT102", 10),
+	}
+
+	// each fetch waits until all four are in flight, which they only are
+	// when rooms and weeks are fetched at once
+	var started sync.WaitGroup
+	started.Add(4)
+	all := make(chan struct{})
+	go func() { started.Wait(); close(all) }()
+
+	stubFetch(t, func(room string, week int) (*Timetable, error) {
+		started.Done()
+		select {
+		case <-all:
+		case <-time.After(time.Second):
+			return nil, ers.New("fetched one at a time")
+		}
+
+		for _, tt := range tts {
+			if tt.Room == room && tt.Week == week {
+				return tt, nil
+			}
+		}
+		return nil, ers.New(room + ": no response")
+	})
+
+	events, freshness, err := FeedEvents([]string{"IT101", "IT102"}, []int{10, 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a block a weekday, two on the Monday IT101 is busy at 10:15
+	if len(events) != 16 {
+		t.Errorf("got %d events, want 16", len(events))
+	}
+	for i := 1; i < len(events); i++ {
+		if events[i].Start.Before(events[i-1].Start) {
+			t.Errorf("event %d starts before event %d", i, i-1)
+		}
+	}
+	if !reflect.DeepEqual(freshness.Unavailable, []string{"IT102"}) {
+		t.Errorf("unavailable = %v, want IT102 in week 11", freshness.Unavailable)
+	}
+}
+
+func TestFeedEventsUnavailable(t *testing.T) {
+	stubFetch(t, func(room string, week int) (*Timetable, error) {
+		return nil, ers.New(room + ": no response")
+	})
+
+	if _, _, err := FeedEvents([]string{"IT101", "IT102"}, []int{10, 11}); err != ErrUpstreamUnavailable {
+		t.Errorf("err = %v, want ErrUpstreamUnavailable", err)
+	}
+}
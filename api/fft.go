@@ -1,7 +1,7 @@
 package findfreetimes
 
 import (
-	"bufio"
+	"context"
 	ers "errors"
 	console "fmt"
 	"io"
@@ -12,18 +12,27 @@ import (
 	"strconv"
 	s "strings"
 	"time"
-
-	"github.com/PuerkitoBio/goquery"
 )
 
 var htmlFilesPath = "null"
 var maxCmds = 200
-var cmds = 0
-var tolerableCurlErrorCodes = map[string]string{"6": "Couldn't resolve host. The given remote host was not resolved", "35": "SSL connect error. The SSL handshaking failed.", "55": "Failed sending network data.", "56": "Failure in receiving network data."}
+
+// DefaultWeek is the academic week searched when none is given.
+const DefaultWeek = 10
+
+var maxAttempts = 3
+var fetchTimeout = 10 * time.Second
+var retryDelay = 500 * time.Millisecond
+
+// fetchBudget caps a fetch, retries included, so that a request waiting for
+// one is answered within Heroku's 30 second router timeout.
+var fetchBudget = 25 * time.Second
+var tolerableCurlErrorCodes = map[string]string{"6": "Couldn't resolve host. The given remote host was not resolved", "28": "Operation timeout.", "35": "SSL connect error. The SSL handshaking failed.", "55": "Failed sending network data.", "56": "Failure in receiving network data."}
 
 //var NORMAL_ROOMS = []string{"223","224","225","226","227","228","229","230","AG03","AG04","AG07","AG08","AG09","AG10","AG14","AG15","AG16","AG18","AG20","AG21","AG25","AG26","AG27","AG31","AG32","AG33","AG34","AL1","AL2","AL3","AT103","AT104","AT105","AT107","AT108","AT109","AT110","AT111","AT112","AT121","AT126","AT130","B01","B02","B03","B07","B08","B09","B09A","B10","B11","B12","B13","B15","B16","B18","B19","B20","B21","BETL","BL1","BL14","BL2","BL3","BL4","BL9","BW1","C001","C002","C003","C004","C005","C014","C07","C11","C111","C115","C204","C206","C212","C23","C24","C25","C26","C27","C28","C29","C30","C31","C32","C33","C34","C35","C38","C39","C39A","C42","C47","C48","C48A","C51","CL1","CL2","CL3","CL4","D01","D02","D04","D05","D08","D11","D12","D25","E03","E04","E07","E13","E15","E19A","E19B","ETRC1","ETRC2","ETRC3","F01","F02","F03","F04","F06","F07","F09","F20","F23","F26","F27","F28","F28A","F29","F30","FTG10","FTG11","FTG12","FTG13","FTG14","FTG15","FTG18","FTG19","FTG20","FTG22","FTG23","FTG24","FTG25","FTG29","G12","G17","G18","G19","G20","HA 06","HA 07","HA 08","HA 17","HA 18","HA 21","HA 22","TL114","TL116","TL120","TL121","TL128","TL129","TL157","TL158","TL159","TL221","TL225","TL228","TL235","TL236","TL238","TL244(A)","TL244(B)","TL245","TL249","TL250","TL251","TL252","W02","W03","W04","W05","W06","W07","W08","W09","W10","W11","W12","W13","W14","W18","W19","W20","W21",}
 var rooms = []string{"IT101", "IT102", "IT103", "IT118", "IT119", "IT120", "IT201", "IT202", "IT203", "IT220", "IT221", "IT222", "ITG01", "ITG02", "ITG03", "ITG17", "ITG18", "ITG19"}
 var supportedTimes = []string{"9:15", "10:15", "11:15", "12:15", "13:15", "14:15", "15:15", "16:15"}
+var weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
 var monRows = []int{2, 3, 4, 5, 6, 7, 8, 9, 10}
 var tueRows = []int{11, 12, 13, 14, 15, 16, 17, 18, 19}
 var wedRows = []int{20, 21, 22, 23, 24, 25, 26, 27, 28}
@@ -32,8 +41,6 @@ var friRows = []int{38, 39, 40, 41, 42, 43, 44, 45, 46}
 var timeSelector = "td:nth-child(1) > small"
 var moduleSelector = "td:nth-child(2) > small > small"
 
-var history = ""
-
 type RoomTimes struct {
 	Room  string   `json:"room"`
 	Times []string `json:"times"`
@@ -88,9 +95,10 @@ func clockwise(a string, b string) bool {
 	return bT > aT
 }
 
-func contains(time string, times []string) bool {
-	for _, t := range times {
-		if t == time {
+// Contains tells whether value is one of values.
+func Contains(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
 			return true
 		}
 	}
@@ -98,7 +106,28 @@ func contains(time string, times []string) bool {
 }
 
 func validTime(time string) bool {
-	return contains(time, supportedTimes)
+	return Contains(time, supportedTimes)
+}
+
+func IsRoom(room string) bool {
+	return Contains(room, rooms)
+}
+
+func IsWeekday(weekday string) bool {
+	return Contains(weekday, weekdays)
+}
+
+func IsSupportedTime(time string) bool {
+	return validTime(time)
+}
+
+// InOrder reports whether the startTime slot does not come after endTime's.
+func InOrder(startTime string, endTime string) bool {
+	return slotIndex(startTime) <= slotIndex(endTime)
+}
+
+func GetSupportedTimes() []string {
+	return supportedTimes
 }
 
 func getTimes(startTime string, endTime string) ([]string, error) {
@@ -129,22 +158,73 @@ func GetAllRooms() []string {
 	return rooms
 }
 
-func GetHistory() string {
-	return history
+func GetWeekdays() []string {
+	return weekdays
 }
 
-func Find(weekday string, startTime string, endTime string, roomsToFind []string) ([]RoomTimes, error) {
-	history = history + " " + weekday + " [" + startTime + "->" + endTime + "] ("
+// Freshness tells how current the timetables behind an answer are. Stale is
+// set when any of them is older than CacheTTL, and Age is the age of the
+// oldest one. Rooms that have never been fetched successfully are listed in
+// Unavailable. Version is the newest version among them, so it changes
+// whenever any of them does, and Modified the latest time one changed.
+type Freshness struct {
+	Unavailable []string
+	Stale       bool
+	Age         time.Duration
+	Version     uint64
+	Modified    time.Time
+}
 
-	for _, room := range roomsToFind {
-		history = history + room + ", "
-	}
+// Result is what Find answers with. Rooms that are not in the catalog are
+// not looked up and are listed in Unknown. Each room is searched once, however
+// often it is asked for.
+type Result struct {
+	Rooms   []RoomTimes
+	Unknown []string
+	Times   []string // the times searched
+	Freshness
+
+	timetables map[string]*Timetable
+	asked      []string
+}
 
-	history = history + "); \n"
+// Asked is the distinct rooms asked for, unknown ones included, in the
+// order they were first asked for.
+func (r *Result) Asked() []string {
+	return r.asked
+}
 
-	channel := make(chan RoomTimes)
+type roomSnapshot struct {
+	room     string
+	snapshot *Snapshot
+	err      error
+}
+
+func Find(weekday string, startTime string, endTime string, roomsToFind []string) (*Result, error) {
+	return FindEach(DefaultWeek, weekday, startTime, endTime, roomsToFind, nil)
+}
+
+// FindEach is Find in the given week, also handing the free times of each
+// room to found, when not nil, as soon as its timetable is available.
+func FindEach(week int, weekday string, startTime string, endTime string, roomsToFind []string, found func(RoomTimes)) (*Result, error) {
+	return FindContext(context.Background(), week, weekday, startTime, endTime, roomsToFind, found)
+}
+
+// FindContext is FindEach, giving up with ctx.Err() once ctx is done. The
+// fetches already started still fill the cache.
+func FindContext(ctx context.Context, week int, weekday string, startTime string, endTime string, roomsToFind []string, found func(RoomTimes)) (*Result, error) {
+	return FindProgress(ctx, week, weekday, startTime, endTime, roomsToFind, found, nil)
+}
 
-	day, dayErr := getRows(weekday)
+// FindProgress is FindContext, also calling searched, when not nil, once for
+// each distinct room asked for as soon as the search is done with it, whether
+// it was found, could not be fetched or is not in the catalog.
+func FindProgress(ctx context.Context, week int, weekday string, startTime string, endTime string, roomsToFind []string, found func(RoomTimes), searched func(room string)) (*Result, error) {
+	if !validWeek(week) {
+		return nil, ErrInvalidWeek
+	}
+
+	_, dayErr := getRows(weekday)
 	if dayErr != nil {
 		return nil, dayErr
 	}
@@ -154,90 +234,170 @@ func Find(weekday string, startTime string, endTime string, roomsToFind []string
 		return nil, timesErr
 	}
 
-	//do query for each room
+	result := &Result{Rooms: make([]RoomTimes, 0), Times: times, timetables: map[string]*Timetable{}}
+	known := make([]string, 0, len(roomsToFind))
+
 	for _, room := range roomsToFind {
-		go process(day, times, room, channel)
+		switch {
+		case Contains(room, known) || Contains(room, result.Unknown):
+			// asked for twice, searched once
+		case IsRoom(room):
+			known = append(known, room)
+			result.asked = append(result.asked, room)
+		default:
+			result.Unknown = append(result.Unknown, room)
+			result.asked = append(result.asked, room)
+			if searched != nil {
+				searched(room)
+			}
+		}
 	}
 
-	result := make([]RoomTimes, 0)
+	freshness, err := collectEach(ctx, known, week, searched, func(tt *Timetable) {
+		result.timetables[tt.Room] = tt
+		roomTimes := RoomTimes{tt.Room, tt.freeTimes(weekday, times)}
+		sort.Strings(roomTimes.Times)
 
-	dataCount := 0
-	for roomTimes := range channel {
-		cmds = cmds - 1
-		dataCount = dataCount + 1
+		if found != nil {
+			found(roomTimes)
+		}
 
 		if len(roomTimes.Times) > 0 {
-			sort.Strings(roomTimes.Times)
-			PrintRTS(roomTimes)
-			result = append(result, roomTimes)
+			result.Rooms = append(result.Rooms, roomTimes)
 		}
+	})
 
-		if dataCount == len(roomsToFind) {
-			clean()
-			close(channel)
-		}
+	if err != nil {
+		return nil, err
 	}
 
+	result.Freshness = freshness
 	return result, nil
 }
 
-func process(day []int, times []string, room string, channel chan RoomTimes) {
-	for cmds >= maxCmds {
-		time.Sleep(70 * time.Millisecond)
+// collect gets the timetables of roomsToFind in a week concurrently, handing
+// each one to found as soon as it is available. It fails with
+// ErrUpstreamUnavailable when none is, or with ctx.Err() when ctx is done
+// first.
+func collect(ctx context.Context, roomsToFind []string, week int, found func(tt *Timetable)) (Freshness, error) {
+	return collectEach(ctx, roomsToFind, week, nil, found)
+}
+
+// collectEach is collect, also calling fetched, when not nil, with each room
+// once its fetch is over, failed or not.
+func collectEach(ctx context.Context, roomsToFind []string, week int, fetched func(room string), found func(tt *Timetable)) (Freshness, error) {
+	channel := make(chan roomSnapshot, len(roomsToFind))
+
+	//do query for each room
+	for _, room := range roomsToFind {
+		go process(room, week, channel)
 	}
 
-	cmds = cmds + 1
+	freshness := Freshness{}
+
+	for range roomsToFind {
+		var rs roomSnapshot
+		select {
+		case rs = <-channel:
+		case <-ctx.Done():
+			return freshness, ctx.Err()
+		}
+
+		if fetched != nil {
+			fetched(rs.room)
+		}
 
-	doc, err := goquery.NewDocumentFromReader(getHtml(query(room)))
-	check(err)
+		if rs.err != nil {
+			freshness.Unavailable = append(freshness.Unavailable, rs.room)
+			continue
+		}
 
-	freeTimes := make([]string, 0)
+		if rs.snapshot.Stale() {
+			freshness.Stale = true
+		}
 
-	for index, param := range day {
-		if index > 0 {
-			doc.Find(getSelector(param)).Each(func(i int, s *goquery.Selection) {
-				ti, mo := s.Find(timeSelector).Text(), s.Find(moduleSelector).Text()
-				if contains(ti, times) && len(mo) == 2 {
-					freeTimes = append(freeTimes, ti)
-				}
-			})
+		if age := rs.snapshot.Age(); age > freshness.Age {
+			freshness.Age = age
 		}
+
+		freshness.add(rs.snapshot)
+
+		found(rs.snapshot.Timetable)
 	}
 
-	channel <- RoomTimes{room, freeTimes}
+	if len(roomsToFind) > 0 && len(freshness.Unavailable) == len(roomsToFind) {
+		return freshness, ErrUpstreamUnavailable
+	}
+
+	return freshness, nil
 }
 
-func query(room string) string {
-	for true {
+func (f *Freshness) add(snapshot *Snapshot) {
+	if snapshot.Version > f.Version {
+		f.Version = snapshot.Version
+	}
+	if snapshot.ModifiedAt.After(f.Modified) {
+		f.Modified = snapshot.ModifiedAt
+	}
+}
+
+func process(room string, week int, channel chan roomSnapshot) {
+	snapshot, err := timetables.get(room, week)
+	channel <- roomSnapshot{room, snapshot, err}
+}
+
+func query(room string, week int) (string, error) {
+	budget, cancelBudget := context.WithTimeout(context.Background(), fetchBudget)
+	defer cancelBudget()
+
+	var err error
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(budget, fetchTimeout)
+
 		// run Curl
-		_, err := exec.Command("./curlroom.sh", []string{room, htmlFilesPath}...).CombinedOutput()
-
-		if err != nil {
-			errParts := s.Split(string(err.Error()), " ")
-			if len(errParts) == 3 && errParts[0] == "exit" && errParts[1] == "status" && isTolerableCurlErrorCode(errParts[2]) {
-				//hit either code 55 or 56, the network is probably busy
-				console.Println(room + ": " + tolerableCurlErrorCodes[errParts[2]] + ", retrying ...")
-				time.Sleep(10 * time.Millisecond)
-			} else {
-				// neither code 55 nor 56, breaks the program
-				log.Fatal(err)
-			}
+		_, err = exec.CommandContext(ctx, "./curlroom.sh", []string{room, htmlFilesPath, strconv.Itoa(week)}...).CombinedOutput()
+		timedOut := ctx.Err() == context.DeadlineExceeded
+		cancel()
+
+		if err == nil {
+			return room + "-w" + strconv.Itoa(week) + ".html", nil
+		}
+
+		errParts := s.Split(string(err.Error()), " ")
+		if budget.Err() != nil {
+			break
+		} else if timedOut {
+			// studentssp.wit.ie is slow, give it another go
+			console.Println(room + ": no response after " + fetchTimeout.String() + ", retrying ...")
+		} else if len(errParts) == 3 && errParts[0] == "exit" && errParts[1] == "status" && isTolerableCurlErrorCode(errParts[2]) {
+			//hit either code 55 or 56, the network is probably busy
+			console.Println(room + ": " + tolerableCurlErrorCodes[errParts[2]] + ", retrying ...")
 		} else {
+			return "", err
+		}
+
+		select {
+		case <-time.After(time.Duration(attempt) * retryDelay):
+		case <-budget.Done():
+		}
+		if budget.Err() != nil {
 			break
 		}
 	}
 
-	return room + ".html"
+	if budget.Err() != nil {
+		return "", ers.New(room + ": giving up after " + fetchBudget.String() + " (" + err.Error() + ")")
+	}
+	return "", ers.New(room + ": giving up after " + strconv.Itoa(maxAttempts) + " attempts (" + err.Error() + ")")
 }
 
-func clean() {
-	_, err := exec.Command("./clean.sh", []string{htmlFilesPath}...).CombinedOutput()
-	check(err)
+func getHtml(path string) (io.ReadCloser, error) {
+	return os.Open(htmlFilesPath + "/" + path)
 }
 
-func getHtml(path string) io.Reader {
-	file, err := os.Open(htmlFilesPath + "/" + path)
-	check(err)
-
-	return bufio.NewReader(file)
+func removeHtml(path string) {
+	if err := os.Remove(htmlFilesPath + "/" + path); err != nil {
+		console.Println(err)
+	}
 }
@@ -0,0 +1,70 @@
+package findfreetimes
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestFindProgress(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", 10), newTimetable("IT102", 10, "monday 9:15"))
+
+	cases := []struct {
+		name     string
+		rooms    []string
+		searched []string
+		err      error
+	}{
+		{"counts found rooms", []string{"IT101", "IT102"}, []string{"IT101", "IT102"}, nil},
+		{"counts rooms that cannot be fetched", []string{"IT101", "IT103"}, []string{"IT101", "IT103"}, nil},
+		{"counts unknown rooms", []string{"IT101", "XX999"}, []string{"IT101", "XX999"}, nil},
+		{"counts rooms asked for twice once", []string{"IT101", "IT101", "XX999", "XX999"}, []string{"IT101", "XX999"}, nil},
+		{"counts rooms of failed searches", []string{"IT103", "IT118"}, []string{"IT103", "IT118"}, ErrUpstreamUnavailable},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var searched []string
+			_, err := FindProgress(context.Background(), 10, "monday", "9:15", "10:15", c.rooms, nil, func(room string) {
+				mu.Lock()
+				searched = append(searched, room)
+				mu.Unlock()
+			})
+
+			sort.Strings(searched)
+			if err != c.err || !reflect.DeepEqual(searched, c.searched) {
+				t.Errorf("searched %v with %v, want %v with %v", searched, err, c.searched, c.err)
+			}
+		})
+	}
+}
+
+func TestAsked(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", 10), newTimetable("IT102", 10, "monday 9:15"))
+
+	cases := []struct {
+		name  string
+		rooms []string
+		want  []string
+	}{
+		{"lists each room read", []string{"IT101", "IT102"}, []string{"IT101", "IT102"}},
+		{"lists repeated rooms once", []string{"IT101", "IT101", "IT102"}, []string{"IT101", "IT102"}},
+		{"keeps unknown rooms", []string{"IT101", "XX999"}, []string{"IT101", "XX999"}},
+		{"keeps unavailable rooms", []string{"IT101", "IT103"}, []string{"IT101", "IT103"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := FindEach(10, "monday", "9:15", "10:15", c.rooms, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := result.Asked(); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package findfreetimes
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// HistoryRetention is how long searches are kept, HistoryMaxEntries how many.
+// Either is ignored when zero.
+var HistoryRetention = 30 * 24 * time.Hour
+var HistoryMaxEntries = 10000
+
+// HistoryEntry is one search made through Find.
+type HistoryEntry struct {
+	ID        int64     `json:"id"`
+	Time      time.Time `json:"time"`
+	User      string    `json:"user"` // JWT subject of whoever searched
+	Weekday   string    `json:"weekday"`
+	Week      int       `json:"week"`
+	StartTime string    `json:"startTime"`
+	EndTime   string    `json:"endTime"`
+	Rooms     []string  `json:"rooms"`
+	Results   int       `json:"results"` // number of rooms with free times
+	Latency   int64     `json:"latency"` // milliseconds
+}
+
+// HistoryFilter narrows down QueryHistory. Zero fields match everything.
+// After is a cursor: only entries with a greater ID are returned.
+type HistoryFilter struct {
+	From    time.Time
+	To      time.Time
+	User    string
+	Room    string
+	Weekday string
+	After   int64
+	Limit   int
+}
+
+func (f HistoryFilter) matches(entry HistoryEntry) bool {
+	return entry.ID > f.After &&
+		(f.From.IsZero() || !entry.Time.Before(f.From)) &&
+		(f.To.IsZero() || entry.Time.Before(f.To)) &&
+		(f.User == "" || entry.User == f.User) &&
+		(f.Room == "" || Contains(f.Room, entry.Rooms)) &&
+		(f.Weekday == "" || entry.Weekday == f.Weekday)
+}
+
+type searchHistory struct {
+	mu      sync.RWMutex
+	entries []HistoryEntry // ordered by ID
+	lastID  int64
+}
+
+var history = &searchHistory{entries: make([]HistoryEntry, 0)}
+
+func RecordSearch(entry HistoryEntry) HistoryEntry {
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	history.lastID++
+	entry.ID = history.lastID
+	history.entries = append(history.entries, entry)
+	history.prune()
+
+	return entry
+}
+
+// QueryHistory returns up to f.Limit matching entries, oldest first, and the
+// cursor to pass as f.After for the next page, which is 0 on the last page.
+func QueryHistory(f HistoryFilter) ([]HistoryEntry, int64) {
+	history.mu.Lock()
+	history.prune()
+	history.mu.Unlock()
+
+	history.mu.RLock()
+	defer history.mu.RUnlock()
+
+	entries := make([]HistoryEntry, 0)
+
+	for _, entry := range history.entries {
+		if !f.matches(entry) {
+			continue
+		}
+
+		if f.Limit > 0 && len(entries) == f.Limit {
+			return entries, entries[len(entries)-1].ID
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, 0
+}
+
+func GetHistoryEntry(id int64) (HistoryEntry, bool) {
+	history.mu.RLock()
+	defer history.mu.RUnlock()
+
+	if i, ok := history.find(id); ok {
+		return history.entries[i], true
+	}
+
+	return HistoryEntry{}, false
+}
+
+func DeleteHistoryEntry(id int64) bool {
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	i, ok := history.find(id)
+	if ok {
+		history.entries = append(history.entries[:i], history.entries[i+1:]...)
+	}
+
+	return ok
+}
+
+// find must be called with history.mu held.
+func (h *searchHistory) find(id int64) (int, bool) {
+	i := sort.Search(len(h.entries), func(i int) bool { return h.entries[i].ID >= id })
+	return i, i < len(h.entries) && h.entries[i].ID == id
+}
+
+// prune drops entries past the retention policy, it must be called with
+// history.mu held.
+func (h *searchHistory) prune() {
+	drop := 0
+
+	if HistoryMaxEntries > 0 && len(h.entries) > HistoryMaxEntries {
+		drop = len(h.entries) - HistoryMaxEntries
+	}
+
+	if HistoryRetention > 0 {
+		cutoff := time.Now().Add(-HistoryRetention)
+		for drop < len(h.entries) && h.entries[drop].Time.Before(cutoff) {
+			drop++
+		}
+	}
+
+	if drop > 0 {
+		h.entries = append(make([]HistoryEntry, 0, len(h.entries)-drop), h.entries[drop:]...)
+	}
+}
@@ -0,0 +1,165 @@
+package findfreetimes
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubHistory empties the history for the rest of the test.
+func stubHistory(t *testing.T) {
+	saved := history
+	history = &searchHistory{entries: make([]HistoryEntry, 0)}
+	t.Cleanup(func() { history = saved })
+}
+
+func TestRecordSearch(t *testing.T) {
+	stubHistory(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			RecordSearch(HistoryEntry{Time: time.Now(), User: "alice", Weekday: "monday"})
+		}()
+	}
+	wg.Wait()
+
+	entries, _ := QueryHistory(HistoryFilter{})
+	if len(entries) != 50 {
+		t.Fatalf("got %d entries, want 50", len(entries))
+	}
+	for i, entry := range entries {
+		if entry.ID != int64(i+1) {
+			t.Fatalf("entry %d has ID %d, want IDs 1 to 50 in order", i, entry.ID)
+		}
+	}
+}
+
+func TestGetAndDeleteHistoryEntry(t *testing.T) {
+	stubHistory(t)
+
+	first := RecordSearch(HistoryEntry{Time: time.Now(), User: "alice"})
+	second := RecordSearch(HistoryEntry{Time: time.Now(), User: "bob"})
+
+	cases := []struct {
+		name   string
+		delete int64
+		get    int64
+		want   bool
+	}{
+		{"finds a recorded search", 0, second.ID, true},
+		{"does not find an unknown ID", 0, 99, false},
+		{"forgets a deleted search", first.ID, first.ID, false},
+		{"keeps the others", first.ID, second.ID, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.delete != 0 {
+				DeleteHistoryEntry(c.delete)
+			}
+			if entry, ok := GetHistoryEntry(c.get); ok != c.want || ok && entry.ID != c.get {
+				t.Errorf("GetHistoryEntry(%d) = %+v, %v, want found = %v", c.get, entry, ok, c.want)
+			}
+		})
+	}
+
+	if DeleteHistoryEntry(first.ID) {
+		t.Error("deleted the same search twice")
+	}
+}
+
+func TestQueryHistory(t *testing.T) {
+	stubHistory(t)
+
+	now := time.Now()
+	RecordSearch(HistoryEntry{Time: now.Add(-3 * time.Hour), User: "alice", Weekday: "monday", Rooms: []string{"IT101"}})
+	RecordSearch(HistoryEntry{Time: now.Add(-2 * time.Hour), User: "bob", Weekday: "monday", Rooms: []string{"IT102"}})
+	RecordSearch(HistoryEntry{Time: now.Add(-time.Hour), User: "alice", Weekday: "friday", Rooms: []string{"IT101", "IT102"}})
+	RecordSearch(HistoryEntry{Time: now, User: "alice", Weekday: "monday", Rooms: []string{"IT103"}})
+
+	cases := []struct {
+		name     string
+		filter   HistoryFilter
+		wantIDs  []int64
+		wantNext int64
+	}{
+		{"returns everything oldest first", HistoryFilter{}, []int64{1, 2, 3, 4}, 0},
+		{"filters by user", HistoryFilter{User: "alice"}, []int64{1, 3, 4}, 0},
+		{"filters by room", HistoryFilter{Room: "IT102"}, []int64{2, 3}, 0},
+		{"filters by weekday", HistoryFilter{Weekday: "friday"}, []int64{3}, 0},
+		{"includes from and excludes to", HistoryFilter{From: now.Add(-2 * time.Hour), To: now}, []int64{2, 3}, 0},
+		{"pages", HistoryFilter{Limit: 2}, []int64{1, 2}, 2},
+		{"continues after the cursor", HistoryFilter{After: 2, Limit: 2}, []int64{3, 4}, 0},
+		{"pages through matches only", HistoryFilter{User: "alice", Limit: 1}, []int64{1}, 1},
+		{"ends on a full last page", HistoryFilter{After: 3, Limit: 1}, []int64{4}, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			entries, next := QueryHistory(c.filter)
+
+			ids := make([]int64, 0, len(entries))
+			for _, entry := range entries {
+				ids = append(ids, entry.ID)
+			}
+			if !equalIDs(ids, c.wantIDs) || next != c.wantNext {
+				t.Errorf("got %v, next %d, want %v, next %d", ids, next, c.wantIDs, c.wantNext)
+			}
+		})
+	}
+}
+
+func TestHistoryPruning(t *testing.T) {
+	savedRetention, savedMax := HistoryRetention, HistoryMaxEntries
+	defer func() { HistoryRetention, HistoryMaxEntries = savedRetention, savedMax }()
+
+	now := time.Now()
+	cases := []struct {
+		name      string
+		retention time.Duration
+		max       int
+		wantIDs   []int64
+	}{
+		{"keeps everything without limits", 0, 0, []int64{1, 2, 3}},
+		{"drops searches past the retention", 90 * time.Minute, 0, []int64{2, 3}},
+		{"drops the oldest past the maximum", 0, 1, []int64{3}},
+		{"applies both", 3 * time.Hour, 2, []int64{2, 3}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stubHistory(t)
+			HistoryRetention, HistoryMaxEntries = 0, 0
+
+			RecordSearch(HistoryEntry{Time: now.Add(-2 * time.Hour)})
+			RecordSearch(HistoryEntry{Time: now.Add(-time.Hour)})
+			RecordSearch(HistoryEntry{Time: now})
+
+			HistoryRetention, HistoryMaxEntries = c.retention, c.max
+			entries, _ := QueryHistory(HistoryFilter{})
+
+			ids := make([]int64, 0, len(entries))
+			for _, entry := range entries {
+				ids = append(ids, entry.ID)
+			}
+			if !equalIDs(ids, c.wantIDs) {
+				t.Errorf("kept %v, want %v", ids, c.wantIDs)
+			}
+		})
+	}
+}
+
+func equalIDs(a []int64, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,201 @@
+package findfreetimes
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	s "strings"
+	"time"
+)
+
+// CalendarEvent is one VEVENT of an iCalendar. UIDs are derived from the
+// room, week, weekday and start time so re-importing a calendar updates
+// the events instead of duplicating them.
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	Free        bool // shows as free rather than busy time
+}
+
+// Europe/Dublin as of 1996, which is all the academic year needs.
+const dublinTimezone = `BEGIN:VTIMEZONE
+TZID:Europe/Dublin
+BEGIN:DAYLIGHT
+TZOFFSETFROM:+0000
+TZOFFSETTO:+0100
+TZNAME:IST
+DTSTART:19700329T010000
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU
+END:DAYLIGHT
+BEGIN:STANDARD
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0000
+TZNAME:GMT
+DTSTART:19701025T020000
+RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU
+END:STANDARD
+END:VTIMEZONE`
+
+// TimetableEvents turns the bookings of tt into events, merging consecutive
+// slots booked for the same module and group.
+func TimetableEvents(tt *Timetable) []CalendarEvent {
+	events := make([]CalendarEvent, 0)
+
+	for _, weekday := range weekdays {
+		var last *Event
+		for _, slot := range tt.Days[weekday] {
+			if slot.Free || slot.Event == nil {
+				last = nil
+				continue
+			}
+
+			if last != nil && *last == *slot.Event {
+				events[len(events)-1].End = slotAt(tt.Week, weekday, slotEnd(slot.Time))
+				continue
+			}
+
+			events = append(events, CalendarEvent{
+				UID:         uid("booking", tt.Room, tt.Week, weekday, slot.Time),
+				Summary:     eventSummary(slot.Event),
+				Description: slot.Event.Lecturer,
+				Location:    tt.Room,
+				Start:       slotAt(tt.Week, weekday, slot.Time),
+				End:         slotAt(tt.Week, weekday, slotEnd(slot.Time)),
+			})
+			last = slot.Event
+		}
+	}
+
+	return events
+}
+
+// FreeEvents turns free blocks found on weekday of week into events.
+func FreeEvents(blocks []Block, weekday string, week int) []CalendarEvent {
+	events := make([]CalendarEvent, 0, len(blocks))
+
+	for _, block := range blocks {
+		events = append(events, CalendarEvent{
+			UID:      uid("free", block.Room, week, weekday, block.Start),
+			Summary:  block.Room + " is free",
+			Location: block.Room,
+			Start:    slotAt(week, weekday, block.Start),
+			End:      slotAt(week, weekday, block.End),
+			Free:     true,
+		})
+	}
+
+	return events
+}
+
+// WriteCalendar writes events as an iCalendar named name, with times on
+// the campus clock.
+func WriteCalendar(out io.Writer, name string, events []CalendarEvent) error {
+	w := bufio.NewWriter(out)
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	line := func(text string) {
+		w.WriteString(fold(text))
+		w.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//rooms-checker-go//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:" + escapeText(name))
+	line("X-WR-TIMEZONE:Europe/Dublin")
+	for _, tz := range s.Split(dublinTimezone, "\n") {
+		line(tz)
+	}
+
+	for _, event := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + event.UID)
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;TZID=Europe/Dublin:" + event.Start.In(Campus).Format("20060102T150405"))
+		line("DTEND;TZID=Europe/Dublin:" + event.End.In(Campus).Format("20060102T150405"))
+		line("SUMMARY:" + escapeText(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION:" + escapeText(event.Description))
+		}
+		line("LOCATION:" + escapeText(event.Location))
+		line("TRANSP:" + transparency(event))
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+
+	return w.Flush()
+}
+
+// slotAt is when a "9:15" style time falls on weekday of week.
+func slotAt(week int, weekday string, clock string) time.Time {
+	parts := s.Split(clock, ":")
+	hour, err := strconv.Atoi(parts[0])
+	check(err)
+	minute, err := strconv.Atoi(parts[1])
+	check(err)
+
+	day := WeekStart(week).AddDate(0, 0, indexOf(weekday, weekdays))
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, Campus)
+}
+
+func uid(kind string, room string, week int, weekday string, start string) string {
+	return fmt.Sprintf("%s-%s-w%d-%s-%s@rooms-checker-go", kind, s.ToLower(room), week, weekday, s.Replace(start, ":", "", 1))
+}
+
+func eventSummary(event *Event) string {
+	if event.Group == "" {
+		return event.Module
+	}
+	return event.Module + " (" + event.Group + ")"
+}
+
+func transparency(event CalendarEvent) string {
+	if event.Free {
+		return "TRANSPARENT"
+	}
+	return "OPAQUE"
+}
+
+func indexOf(value string, values []string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+var textEscaper = s.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func escapeText(text string) string {
+	return textEscaper.Replace(text)
+}
+
+// fold splits content lines longer than 75 octets, as RFC 5545 requires,
+// without cutting a UTF-8 sequence in half.
+func fold(line string) string {
+	if len(line) <= 75 {
+		return line
+	}
+
+	var b s.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+
+	return b.String()
+}
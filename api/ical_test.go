@@ -0,0 +1,114 @@
+package findfreetimes
+
+import (
+	"bytes"
+	s "strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestFold(t *testing.T) {
+	cases := []struct {
+		name string
+		line string
+	}{
+		{"keeps short lines", "SUMMARY:IT101 is free"},
+		{"keeps lines of exactly 75 octets", "SUMMARY:" + s.Repeat("a", 67)},
+		{"folds long lines", "DESCRIPTION:" + s.Repeat("a", 200)},
+		{"folds between UTF-8 sequences", "DESCRIPTION:" + s.Repeat("é", 100)},
+		{"folds multi-byte runes on the boundary", "SUMMARY:" + s.Repeat("a", 66) + "€€€€"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			folded := fold(c.line)
+
+			for i, part := range s.Split(folded, "\r\n") {
+				if len(part) > 75 {
+					t.Errorf("line %d is %d octets long: %q", i, len(part), part)
+				}
+				if i > 0 && !s.HasPrefix(part, " ") {
+					t.Errorf("continuation line %d does not start with a space: %q", i, part)
+				}
+				if !utf8.ValidString(part) {
+					t.Errorf("line %d cuts a UTF-8 sequence: %q", i, part)
+				}
+			}
+
+			if unfolded := s.Replace(folded, "\r\n ", "", -1); unfolded != c.line {
+				t.Errorf("unfolds to %q, want %q", unfolded, c.line)
+			}
+		})
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"IT101", "IT101"},
+		{"Free rooms: IT101, IT102", `Free rooms: IT101\, IT102`},
+		{`a;b\c`, `a\;b\\c`},
+		{"two\nlines", `two\nlines`},
+	}
+
+	for _, c := range cases {
+		if got := escapeText(c.text); got != c.want {
+			t.Errorf("escapeText(%q) = %q, want %q", c.text, got, c.want)
+		}
+	}
+}
+
+func TestTimetableEvents(t *testing.T) {
+	tt := newTimetable("IT101", 10)
+	tt.Days["monday"][0] = Slot{Time: "9:15", Event: &Event{Module: "Maths", Group: "CS1"}}
+	tt.Days["monday"][1] = Slot{Time: "10:15", Event: &Event{Module: "Maths", Group: "CS1"}}
+	tt.Days["monday"][2] = Slot{Time: "11:15", Event: &Event{Module: "Maths", Group: "CS2"}}
+
+	events := TimetableEvents(tt)
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want the CS1 double and the CS2 single: %+v", len(events), events)
+	}
+
+	double := events[0]
+	if double.Summary != "Maths (CS1)" || double.UID != "booking-it101-w10-monday-915@rooms-checker-go" {
+		t.Errorf("got %+v", double)
+	}
+	// week 10 starts on Monday 6 November 2017, on Greenwich time
+	if want := time.Date(2017, time.November, 6, 9, 15, 0, 0, time.UTC); !double.Start.Equal(want) {
+		t.Errorf("starts %v, want %v", double.Start, want)
+	}
+	if want := time.Date(2017, time.November, 6, 11, 15, 0, 0, time.UTC); !double.End.Equal(want) {
+		t.Errorf("ends %v, want %v", double.End, want)
+	}
+}
+
+func TestWriteCalendar(t *testing.T) {
+	var b bytes.Buffer
+	events := FreeEvents([]Block{{Room: "IT101", Start: "9:15", End: "11:15", Length: 120}}, "monday", 10)
+
+	if err := WriteCalendar(&b, "Free rooms, Monday", events); err != nil {
+		t.Fatal(err)
+	}
+
+	calendar := b.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Free rooms\\, Monday\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Dublin\r\n",
+		"UID:free-it101-w10-monday-915@rooms-checker-go\r\n",
+		"DTSTART;TZID=Europe/Dublin:20171106T091500\r\n",
+		"DTEND;TZID=Europe/Dublin:20171106T111500\r\n",
+		"TRANSP:TRANSPARENT\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !s.Contains(calendar, want) {
+			t.Errorf("calendar lacks %q:\n%s", want, calendar)
+		}
+	}
+	if s.Contains(s.Replace(calendar, "\r\n", "", -1), "\n") {
+		t.Error("calendar has lines not ended by CRLF")
+	}
+}
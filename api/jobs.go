@@ -0,0 +1,163 @@
+package findfreetimes
+
+import (
+	"context"
+	ers "errors"
+	"sync"
+	"time"
+)
+
+// JobRetention is how long finished jobs are kept for their owners to
+// collect. 0 keeps them until the server restarts.
+var JobRetention = time.Hour
+
+// MaxRunningJobsPerUser caps how many jobs one user can have running at once.
+var MaxRunningJobsPerUser = 3
+
+var ErrTooManyJobs = ers.New("Wait for a job to finish or cancel one before starting another")
+
+const (
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// Job is work running in the background, for searches too slow to answer
+// within one request. It does not depend on the request that started it, so
+// it runs on after the client disconnects until it finishes or its owner
+// cancels it.
+type Job struct {
+	ID       int64       `json:"id"`
+	User     string      `json:"-"` // JWT subject of the owner
+	Status   string      `json:"status"`
+	Done     int         `json:"done"`  // rooms searched so far
+	Total    int         `json:"total"` // rooms to search
+	Created  time.Time   `json:"created"`
+	Finished *time.Time  `json:"finished,omitempty"`
+	Result   interface{} `json:"-"` // what the work returned, once done
+	Err      error       `json:"-"` // why the work failed
+}
+
+// JobWork is what a job does. It calls progress for each room it is done
+// with and gives up when ctx is done.
+type JobWork func(ctx context.Context, progress func()) (interface{}, error)
+
+type jobStore struct {
+	mu     sync.Mutex
+	byID   map[int64]*jobEntry
+	lastID int64
+}
+
+type jobEntry struct {
+	job    Job
+	cancel context.CancelFunc
+}
+
+var jobs = &jobStore{byID: make(map[int64]*jobEntry)}
+
+// StartJob runs work for user in the background and returns the job
+// straight away.
+func StartJob(user string, total int, work JobWork) (Job, error) {
+	jobs.mu.Lock()
+	defer jobs.mu.Unlock()
+
+	jobs.prune()
+
+	if MaxRunningJobsPerUser > 0 && jobs.running(user) >= MaxRunningJobsPerUser {
+		return Job{}, ErrTooManyJobs
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	jobs.lastID++
+	entry := &jobEntry{
+		job: Job{
+			ID:      jobs.lastID,
+			User:    user,
+			Status:  JobRunning,
+			Total:   total,
+			Created: time.Now(),
+		},
+		cancel: cancel,
+	}
+	jobs.byID[entry.job.ID] = entry
+
+	go func() {
+		defer cancel()
+
+		result, err := work(ctx, func() {
+			jobs.mu.Lock()
+			entry.job.Done++
+			jobs.mu.Unlock()
+		})
+
+		jobs.mu.Lock()
+		defer jobs.mu.Unlock()
+
+		finished := time.Now()
+		entry.job.Finished = &finished
+		if err != nil {
+			entry.job.Status, entry.job.Err = JobFailed, err
+			return
+		}
+		entry.job.Status, entry.job.Done, entry.job.Result = JobDone, entry.job.Total, result
+	}()
+
+	return entry.job, nil
+}
+
+// GetJob returns the job id, provided user owns it.
+func GetJob(user string, id int64) (Job, bool) {
+	jobs.mu.Lock()
+	defer jobs.mu.Unlock()
+
+	jobs.prune()
+
+	entry, ok := jobs.byID[id]
+	if !ok || entry.job.User != user {
+		return Job{}, false
+	}
+	return entry.job, true
+}
+
+// CancelJob stops the job id if it is still running and forgets it,
+// provided user owns it.
+func CancelJob(user string, id int64) bool {
+	jobs.mu.Lock()
+	defer jobs.mu.Unlock()
+
+	entry, ok := jobs.byID[id]
+	if !ok || entry.job.User != user {
+		return false
+	}
+
+	entry.cancel()
+	delete(jobs.byID, id)
+	return true
+}
+
+// running must be called with jobs.mu held.
+func (j *jobStore) running(user string) int {
+	n := 0
+	for _, entry := range j.byID {
+		if entry.job.User == user && entry.job.Status == JobRunning {
+			n++
+		}
+	}
+	return n
+}
+
+// prune drops the jobs that finished more than JobRetention ago. It must be
+// called with jobs.mu held.
+func (j *jobStore) prune() {
+	if JobRetention <= 0 {
+		return
+	}
+
+	cutoff := time.Now().Add(-JobRetention)
+	for id, entry := range j.byID {
+		if entry.job.Finished != nil && entry.job.Finished.Before(cutoff) {
+			delete(j.byID, id)
+		}
+	}
+}
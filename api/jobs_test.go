@@ -0,0 +1,135 @@
+package findfreetimes
+
+import (
+	"context"
+	ers "errors"
+	"testing"
+	"time"
+)
+
+// waitJob polls the job until it is no longer running.
+func waitJob(t *testing.T, user string, id int64) Job {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, ok := GetJob(user, id)
+		if !ok {
+			t.Fatalf("job %d is gone", id)
+		}
+		if job.Status != JobRunning {
+			return job
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("job %d still running", id)
+	return Job{}
+}
+
+func TestStartJob(t *testing.T) {
+	errWork := ers.New("no timetables")
+
+	cases := []struct {
+		name   string
+		work   JobWork
+		status string
+		done   int
+		result interface{}
+		err    error
+	}{
+		{"finishes with the result", func(ctx context.Context, progress func()) (interface{}, error) {
+			progress()
+			return "rooms", nil
+		}, JobDone, 3, "rooms", nil},
+		{"fails with the error", func(ctx context.Context, progress func()) (interface{}, error) {
+			progress()
+			progress()
+			return nil, errWork
+		}, JobFailed, 2, nil, errWork},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			started, err := StartJob("u-start", 3, c.work)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if started.Status != JobRunning || started.Total != 3 {
+				t.Errorf("started %+v, want a running job of 3 rooms", started)
+			}
+
+			job := waitJob(t, "u-start", started.ID)
+			if job.Status != c.status || job.Done != c.done || job.Result != c.result || job.Err != c.err || job.Finished == nil {
+				t.Errorf("got %+v, want %s with %d done", job, c.status, c.done)
+			}
+		})
+	}
+}
+
+func TestJobOwnership(t *testing.T) {
+	job, _ := StartJob("u-owner", 1, func(ctx context.Context, progress func()) (interface{}, error) { return nil, nil })
+	waitJob(t, "u-owner", job.ID)
+
+	if _, ok := GetJob("u-other", job.ID); ok {
+		t.Error("another user got the job")
+	}
+	if CancelJob("u-other", job.ID) {
+		t.Error("another user cancelled the job")
+	}
+	if !CancelJob("u-owner", job.ID) {
+		t.Error("the owner could not cancel the job")
+	}
+	if _, ok := GetJob("u-owner", job.ID); ok {
+		t.Error("a cancelled job is still there")
+	}
+}
+
+func TestCancelJob(t *testing.T) {
+	cancelled := make(chan struct{})
+	job, _ := StartJob("u-cancel", 1, func(ctx context.Context, progress func()) (interface{}, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+
+	CancelJob("u-cancel", job.ID)
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the work went on after the job was cancelled")
+	}
+}
+
+func TestMaxRunningJobs(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	block := func(ctx context.Context, progress func()) (interface{}, error) {
+		<-release
+		return nil, nil
+	}
+
+	for i := 0; i < MaxRunningJobsPerUser; i++ {
+		if _, err := StartJob("u-max", 1, block); err != nil {
+			t.Fatalf("job %d: %v", i, err)
+		}
+	}
+	if _, err := StartJob("u-max", 1, block); err != ErrTooManyJobs {
+		t.Errorf("err = %v, want ErrTooManyJobs", err)
+	}
+	if _, err := StartJob("u-max-other", 1, block); err != nil {
+		t.Errorf("another user: %v", err)
+	}
+}
+
+func TestJobRetention(t *testing.T) {
+	job, _ := StartJob("u-retention", 1, func(ctx context.Context, progress func()) (interface{}, error) { return nil, nil })
+	waitJob(t, "u-retention", job.ID)
+
+	saved := JobRetention
+	JobRetention = time.Nanosecond
+	defer func() { JobRetention = saved }()
+	time.Sleep(time.Millisecond)
+
+	if _, ok := GetJob("u-retention", job.ID); ok {
+		t.Error("got a job finished longer than JobRetention ago")
+	}
+}
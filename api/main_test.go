@@ -0,0 +1,32 @@
+package findfreetimes
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain runs the tests from an empty directory that is removed
+// afterwards, so that a fetch outliving its test's stub cannot dump
+// timetables into the tree.
+func TestMain(m *testing.M) {
+	os.Exit(runInScratchDir(m))
+}
+
+func runInScratchDir(m *testing.M) int {
+	wd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	dir, err := os.MkdirTemp("", "rooms-checker")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	defer os.Chdir(wd)
+
+	return m.Run()
+}
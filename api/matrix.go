@@ -0,0 +1,79 @@
+package findfreetimes
+
+import "context"
+
+// Matrix is the availability of rooms over several days, ready to be drawn
+// as a heatmap.
+type Matrix struct {
+	Week  int
+	Days  []string
+	Times []string
+	Rooms []RoomMatrix
+	Freshness
+}
+
+type RoomMatrix struct {
+	Room string   `json:"room"`
+	Free [][]bool `json:"free"` // Free[d][t] tells whether the room is free on Days[d] at Times[t]
+}
+
+// FindMatrix checks roomsToFind on each of days between startTime and
+// endTime. No days means the whole week, no times the whole day and no rooms
+// every room.
+func FindMatrix(week int, days []string, startTime string, endTime string, roomsToFind []string) (*Matrix, error) {
+	if !validWeek(week) {
+		return nil, ErrInvalidWeek
+	}
+
+	if len(days) == 0 {
+		days = weekdays
+	}
+
+	for _, weekday := range days {
+		if _, dayErr := getRows(weekday); dayErr != nil {
+			return nil, dayErr
+		}
+	}
+
+	if startTime == "" && endTime == "" {
+		startTime, endTime = supportedTimes[0], supportedTimes[len(supportedTimes)-1]
+	}
+
+	times, timesErr := getTimes(startTime, endTime)
+	if timesErr != nil {
+		return nil, timesErr
+	}
+
+	if len(roomsToFind) == 0 {
+		roomsToFind = rooms
+	}
+
+	matrix := &Matrix{Week: week, Days: days, Times: times, Rooms: make([]RoomMatrix, len(roomsToFind))}
+	position := map[string]int{}
+	for i, room := range roomsToFind {
+		position[room] = i
+		matrix.Rooms[i] = RoomMatrix{Room: room, Free: make([][]bool, 0)}
+	}
+
+	freshness, err := collect(context.Background(), roomsToFind, week, func(tt *Timetable) {
+		free := make([][]bool, len(days))
+
+		for d, weekday := range days {
+			free[d] = make([]bool, len(times))
+			freeTimes := tt.freeTimes(weekday, times)
+
+			for t, time := range times {
+				free[d][t] = Contains(time, freeTimes)
+			}
+		}
+
+		matrix.Rooms[position[tt.Room]].Free = free
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	matrix.Freshness = freshness
+	return matrix, nil
+}
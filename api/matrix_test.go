@@ -0,0 +1,63 @@
+package findfreetimes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindMatrix(t *testing.T) {
+	stubTimetables(t,
+		newTimetable("IT101", 10, "monday 9:15", "tuesday 10:15"),
+		newTimetable("IT102", 10),
+	)
+
+	cases := []struct {
+		name      string
+		days      []string
+		startTime string
+		endTime   string
+		rooms     []string
+		wantDays  int
+		wantTimes int
+		want      []RoomMatrix
+		wantErr   error
+	}{
+		{"tells each room's slots per day", []string{"monday", "tuesday"}, "9:15", "10:15", []string{"IT101", "IT102"}, 2, 2, []RoomMatrix{
+			{"IT101", [][]bool{{false, true}, {true, false}}},
+			{"IT102", [][]bool{{true, true}, {true, true}}},
+		}, nil},
+		{"keeps the order of the rooms asked for", []string{"monday"}, "9:15", "9:15", []string{"IT102", "IT101"}, 1, 1, []RoomMatrix{
+			{"IT102", [][]bool{{true}}},
+			{"IT101", [][]bool{{false}}},
+		}, nil},
+		{"leaves unavailable rooms empty", []string{"monday"}, "9:15", "9:15", []string{"IT101", "IT103"}, 1, 1, []RoomMatrix{
+			{"IT101", [][]bool{{false}}},
+			{"IT103", [][]bool{}},
+		}, nil},
+		{"defaults to the whole week and day", nil, "", "", []string{"IT102"}, 5, 8, nil, nil},
+		{"rejects unknown weekdays", []string{"sunday"}, "", "", nil, 0, 0, nil, nil},
+		{"fails when no room is available", []string{"monday"}, "", "", []string{"IT103"}, 0, 0, nil, ErrUpstreamUnavailable},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			matrix, err := FindMatrix(10, c.days, c.startTime, c.endTime, c.rooms)
+			if c.wantDays == 0 {
+				if err == nil || c.wantErr != nil && err != c.wantErr {
+					t.Fatalf("err = %v, want %v", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(matrix.Days) != c.wantDays || len(matrix.Times) != c.wantTimes {
+				t.Errorf("got %d days and %d times, want %d and %d", len(matrix.Days), len(matrix.Times), c.wantDays, c.wantTimes)
+			}
+			if c.want != nil && !reflect.DeepEqual(matrix.Rooms, c.want) {
+				t.Errorf("got %+v, want %+v", matrix.Rooms, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package findfreetimes
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	s "strings"
+	"time"
+)
+
+// FreeRoom is a room free at the moment FreeNow was asked about.
+type FreeRoom struct {
+	Room      string `json:"room"`
+	FreeUntil string `json:"freeUntil"`
+	FreeFor   int    `json:"freeFor"` // minutes
+}
+
+// NowResult is what FreeNow answers with.
+type NowResult struct {
+	Week    int
+	Weekday string
+	Slot    string // the slot now falls in, empty outside teaching hours
+	Rooms   []FreeRoom
+	Freshness
+}
+
+// FreeNow finds the rooms free at now, on the campus clock, that stay free
+// for at least minFree. Those free the longest come first. Outside teaching
+// hours and at the weekend no room is timetabled, so none is found.
+func FreeNow(now time.Time, minFree time.Duration) (*NowResult, error) {
+	now = now.In(Campus)
+
+	week, err := WeekAt(now)
+	if err != nil {
+		return nil, err
+	}
+
+	weekday := s.ToLower(now.Weekday().String())
+	result := &NowResult{Week: week, Weekday: weekday, Rooms: make([]FreeRoom, 0)}
+
+	slot := -1
+	for i, t := range supportedTimes {
+		if !now.Before(clockAt(now, t)) && now.Before(clockAt(now, slotEnd(t))) {
+			slot = i
+		}
+	}
+	if slot < 0 || !Contains(weekday, weekdays) {
+		return result, nil
+	}
+
+	result.Slot = supportedTimes[slot]
+
+	freshness, err := collect(context.Background(), rooms, week, func(tt *Timetable) {
+		free := tt.freeFrom(weekday, slot)
+		if len(free) == 0 {
+			return
+		}
+
+		freeUntil := slotEnd(free[len(free)-1])
+		freeFor := clockAt(now, freeUntil).Sub(now)
+
+		if freeFor >= minFree {
+			result.Rooms = append(result.Rooms, FreeRoom{tt.Room, freeUntil, int(freeFor / time.Minute)})
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	result.Freshness = freshness
+
+	sort.Slice(result.Rooms, func(i, j int) bool {
+		if result.Rooms[i].FreeFor != result.Rooms[j].FreeFor {
+			return result.Rooms[i].FreeFor > result.Rooms[j].FreeFor
+		}
+		return result.Rooms[i].Room < result.Rooms[j].Room
+	})
+
+	return result, nil
+}
+
+// freeFrom returns the run of free slots starting at the slot-th one.
+func (tt *Timetable) freeFrom(weekday string, slot int) []string {
+	free := make([]string, 0)
+
+	for _, sl := range tt.Days[weekday] {
+		i := slotIndex(sl.Time)
+		if i < slot {
+			continue
+		}
+		if !sl.Free || i != slot+len(free) {
+			break
+		}
+		free = append(free, sl.Time)
+	}
+
+	return free
+}
+
+// clockAt is the moment a "15:04" time happens on the day of t.
+func clockAt(t time.Time, clock string) time.Time {
+	parts := s.Split(clock, ":")
+	hour, err := strconv.Atoi(parts[0])
+	check(err)
+	minute, err := strconv.Atoi(parts[1])
+	check(err)
+
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location())
+}
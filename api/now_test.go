@@ -0,0 +1,51 @@
+package findfreetimes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFreeNow(t *testing.T) {
+	stubTimetables(t,
+		newTimetable("IT101", 10),
+		newTimetable("IT102", 10, "monday 10:15"),
+		newTimetable("IT103", 10, "monday 9:15"),
+	)
+
+	monday := func(hour int, minute int) time.Time {
+		return time.Date(2017, time.November, 6, hour, minute, 0, 0, Campus)
+	}
+
+	cases := []struct {
+		name     string
+		now      time.Time
+		minFree  time.Duration
+		wantSlot string
+		want     []FreeRoom
+		wantErr  error
+	}{
+		{"finds the rooms free now, longest first", monday(9, 30), 0, "9:15", []FreeRoom{{"IT101", "17:15", 465}, {"IT102", "10:15", 45}}, nil},
+		{"keeps those free long enough", monday(9, 30), time.Hour, "9:15", []FreeRoom{{"IT101", "17:15", 465}}, nil},
+		{"reads the time on the campus clock", monday(9, 30).UTC(), time.Hour, "9:15", []FreeRoom{{"IT101", "17:15", 465}}, nil},
+		{"finds nothing before teaching starts", monday(8, 0), 0, "", []FreeRoom{}, nil},
+		{"finds nothing after teaching ends", monday(17, 15), 0, "", []FreeRoom{}, nil},
+		{"finds nothing at the weekend", monday(10, 0).AddDate(0, 0, 5), 0, "", []FreeRoom{}, nil},
+		{"fails out of term", time.Date(2019, time.January, 7, 10, 0, 0, 0, Campus), 0, "", nil, ErrOutOfTerm},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := FreeNow(c.now, c.minFree)
+			if err != c.wantErr {
+				t.Fatalf("err = %v, want %v", err, c.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if result.Slot != c.wantSlot || !reflect.DeepEqual(result.Rooms, c.want) {
+				t.Errorf("got slot %q and %+v, want %q and %+v", result.Slot, result.Rooms, c.wantSlot, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package findfreetimes
+
+import "sort"
+
+// Kinds of near-miss suggestions.
+const (
+	SuggestPartial  = "partial"   // the room is free for most of the window
+	SuggestShifted  = "shifted"   // the room is free for the whole window at another time
+	SuggestOtherDay = "other-day" // the room is free for the whole window on another day
+)
+
+// most suggestions of each kind
+var maxSuggestions = 5
+
+// Suggestion is a near miss, with how far it departs from the search.
+type Suggestion struct {
+	Kind      string  `json:"kind"`
+	Room      string  `json:"room"`
+	Weekday   string  `json:"weekday"`
+	StartTime string  `json:"startTime"`
+	EndTime   string  `json:"endTime"`
+	Coverage  float64 `json:"coverage"`  // share of the requested window the room is free for
+	Shift     int     `json:"shift"`     // minutes the window was moved by
+	DayOffset int     `json:"dayOffset"` // days the window was moved by
+}
+
+// Suggest looks for near misses when no room is free for the whole window,
+// it returns nil when one is. It tries rooms free for most of the window
+// first, then the same rooms at the nearest time of the day they are free
+// for as long, then at the same time on the nearest other weekday.
+func Suggest(result *Result, weekday string, startTime string, endTime string) []Suggestion {
+	times, err := getTimes(startTime, endTime)
+	if err != nil {
+		return nil
+	}
+
+	for _, roomTimes := range result.Rooms {
+		if len(roomTimes.Times) == len(times) {
+			return nil
+		}
+	}
+
+	suggestions := make([]Suggestion, 0)
+	suggestions = append(suggestions, partial(result, weekday, times)...)
+	suggestions = append(suggestions, shifted(result, weekday, times)...)
+	suggestions = append(suggestions, otherDay(result, weekday, times)...)
+
+	return suggestions
+}
+
+func partial(result *Result, weekday string, times []string) []Suggestion {
+	suggestions := make([]Suggestion, 0)
+
+	for _, roomTimes := range result.Rooms {
+		coverage := float64(len(roomTimes.Times)) / float64(len(times))
+		if coverage < 0.5 {
+			continue
+		}
+
+		suggestions = append(suggestions, Suggestion{
+			Kind:      SuggestPartial,
+			Room:      roomTimes.Room,
+			Weekday:   weekday,
+			StartTime: times[0],
+			EndTime:   times[len(times)-1],
+			Coverage:  coverage,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Coverage > suggestions[j].Coverage
+	})
+
+	return capped(suggestions)
+}
+
+func shifted(result *Result, weekday string, times []string) []Suggestion {
+	suggestions := make([]Suggestion, 0)
+	first := slotIndex(times[0])
+
+	for _, room := range sortedRooms(result) {
+		tt := result.timetables[room]
+
+		for _, shift := range offsets(len(supportedTimes)) {
+			start := first + shift
+			if start < 0 || start+len(times) > len(supportedTimes) {
+				continue
+			}
+
+			window := supportedTimes[start : start+len(times)]
+			if len(tt.freeTimes(weekday, window)) == len(window) {
+				suggestions = append(suggestions, Suggestion{
+					Kind:      SuggestShifted,
+					Room:      room,
+					Weekday:   weekday,
+					StartTime: window[0],
+					EndTime:   window[len(window)-1],
+					Coverage:  1,
+					Shift:     shift * slotLength,
+				})
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return abs(suggestions[i].Shift) < abs(suggestions[j].Shift)
+	})
+
+	return capped(suggestions)
+}
+
+func otherDay(result *Result, weekday string, times []string) []Suggestion {
+	suggestions := make([]Suggestion, 0)
+	today := -1
+	for i, day := range weekdays {
+		if day == weekday {
+			today = i
+		}
+	}
+
+	for _, room := range sortedRooms(result) {
+		tt := result.timetables[room]
+
+		for _, offset := range offsets(len(weekdays)) {
+			day := today + offset
+			if day < 0 || day >= len(weekdays) {
+				continue
+			}
+
+			if len(tt.freeTimes(weekdays[day], times)) == len(times) {
+				suggestions = append(suggestions, Suggestion{
+					Kind:      SuggestOtherDay,
+					Room:      room,
+					Weekday:   weekdays[day],
+					StartTime: times[0],
+					EndTime:   times[len(times)-1],
+					Coverage:  1,
+					DayOffset: offset,
+				})
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return abs(suggestions[i].DayOffset) < abs(suggestions[j].DayOffset)
+	})
+
+	return capped(suggestions)
+}
+
+// offsets lists 1, -1, 2, -2 ... up to n, nearest first.
+func offsets(n int) []int {
+	result := make([]int, 0, 2*n)
+	for i := 1; i <= n; i++ {
+		result = append(result, i, -i)
+	}
+	return result
+}
+
+func sortedRooms(result *Result) []string {
+	rooms := make([]string, 0, len(result.timetables))
+	for room := range result.timetables {
+		rooms = append(rooms, room)
+	}
+	sort.Strings(rooms)
+	return rooms
+}
+
+func capped(suggestions []Suggestion) []Suggestion {
+	if len(suggestions) > maxSuggestions {
+		return suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
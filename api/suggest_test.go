@@ -0,0 +1,51 @@
+package findfreetimes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	stubTimetables(t,
+		newTimetable("IT101", 10, "monday 11:15"),
+		newTimetable("IT102", 10, "monday 9:15", "monday 10:15"),
+		newTimetable("IT103", 10),
+	)
+
+	cases := []struct {
+		name  string
+		rooms []string
+		want  []Suggestion
+	}{
+		{"suggests nothing when a room is free throughout", []string{"IT101", "IT103"}, nil},
+		{"suggests partial, shifted and other day near misses", []string{"IT101", "IT102"}, []Suggestion{
+			{Kind: SuggestPartial, Room: "IT101", Weekday: "monday", StartTime: "9:15", EndTime: "11:15", Coverage: 2.0 / 3},
+			{Kind: SuggestShifted, Room: "IT102", Weekday: "monday", StartTime: "11:15", EndTime: "13:15", Coverage: 1, Shift: 120},
+			{Kind: SuggestShifted, Room: "IT101", Weekday: "monday", StartTime: "12:15", EndTime: "14:15", Coverage: 1, Shift: 180},
+			{Kind: SuggestOtherDay, Room: "IT101", Weekday: "tuesday", StartTime: "9:15", EndTime: "11:15", Coverage: 1, DayOffset: 1},
+			{Kind: SuggestOtherDay, Room: "IT102", Weekday: "tuesday", StartTime: "9:15", EndTime: "11:15", Coverage: 1, DayOffset: 1},
+		}},
+		{"leaves out rooms free for less than half the window", []string{"IT102"}, []Suggestion{
+			{Kind: SuggestShifted, Room: "IT102", Weekday: "monday", StartTime: "11:15", EndTime: "13:15", Coverage: 1, Shift: 120},
+			{Kind: SuggestOtherDay, Room: "IT102", Weekday: "tuesday", StartTime: "9:15", EndTime: "11:15", Coverage: 1, DayOffset: 1},
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := FindEach(10, "monday", "9:15", "11:15", c.rooms, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Suggest(result, "monday", "9:15", "11:15"); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %+v\nwant %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestOffsets(t *testing.T) {
+	if got, want := offsets(3), []int{1, -1, 2, -2, 3, -3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package findfreetimes
+
+import (
+	"bufio"
+	s "strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Timetable is the parsed week of a room, slots keyed by weekday.
+type Timetable struct {
+	Room string
+	Week int
+	Days map[string][]Slot
+}
+
+type Slot struct {
+	Time  string `json:"time"`
+	Free  bool   `json:"free"`
+	Event *Event `json:"event,omitempty"` // what the room is booked for, nil when free
+}
+
+type Event struct {
+	Module   string `json:"module"`
+	Group    string `json:"group,omitempty"`
+	Lecturer string `json:"lecturer,omitempty"`
+}
+
+func (tt *Timetable) freeTimes(weekday string, times []string) []string {
+	freeTimes := make([]string, 0)
+
+	for _, slot := range tt.Days[weekday] {
+		if Contains(slot.Time, times) && slot.Free {
+			freeTimes = append(freeTimes, slot.Time)
+		}
+	}
+
+	return freeTimes
+}
+
+func fetchTimetable(room string, week int) (*Timetable, error) {
+	curlSlots <- struct{}{}
+	path, err := query(room, week)
+	<-curlSlots
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer removeHtml(path)
+
+	html, err := getHtml(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer html.Close()
+
+	doc, err := goquery.NewDocumentFromReader(bufio.NewReader(html))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTimetable(room, week, doc), nil
+}
+
+func parseTimetable(room string, week int, doc *goquery.Document) *Timetable {
+	tt := &Timetable{Room: room, Week: week, Days: map[string][]Slot{}}
+
+	for _, weekday := range weekdays {
+		day, _ := getRows(weekday)
+		slots := make([]Slot, 0)
+
+		for index, param := range day {
+			if index > 0 {
+				doc.Find(getSelector(param)).Each(func(i int, s *goquery.Selection) {
+					slots = append(slots, parseSlot(s))
+				})
+			}
+		}
+
+		tt.Days[weekday] = slots
+	}
+
+	return tt
+}
+
+// An empty module cell only holds a &nbsp;, which is two bytes long.
+func parseSlot(row *goquery.Selection) Slot {
+	slot := Slot{Time: row.Find(timeSelector).Text()}
+	cell := row.Find(moduleSelector)
+
+	if len(cell.Text()) == 2 {
+		slot.Free = true
+	} else {
+		slot.Event = parseEvent(cell)
+	}
+
+	return slot
+}
+
+// parseEvent reads a booked module cell, which lists the module, the group
+// and the lecturer on lines of their own.
+func parseEvent(cell *goquery.Selection) *Event {
+	lines := make([]string, 0)
+
+	cell.Contents().Each(func(i int, node *goquery.Selection) {
+		if goquery.NodeName(node) == "br" {
+			return
+		}
+		if line := s.TrimSpace(node.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	})
+
+	event := &Event{Module: s.TrimSpace(cell.Text())}
+
+	if len(lines) > 1 {
+		event.Module = lines[0]
+		event.Group = lines[1]
+	}
+	if len(lines) > 2 {
+		event.Lecturer = s.Join(lines[2:], ", ")
+	}
+
+	return event
+}
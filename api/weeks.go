@@ -0,0 +1,49 @@
+package findfreetimes
+
+import (
+	ers "errors"
+	"time"
+	_ "time/tzdata" // Heroku dynos may not ship a zoneinfo database
+)
+
+// Campus is the time zone timetables are written in.
+var Campus = loadLocation("Europe/Dublin")
+
+var ErrOutOfTerm = ers.New("There is no timetable for this week")
+
+// The timetable form offers weeks 2 (11-SEP-17) to 51 (20-AUG-18).
+const FirstWeek = 2
+const LastWeek = 51
+
+var weekOneStart = time.Date(2017, time.September, 4, 0, 0, 0, 0, Campus)
+
+func validWeek(week int) bool {
+	return week >= FirstWeek && week <= LastWeek
+}
+
+// WeekStart is the Monday an academic week starts on.
+func WeekStart(week int) time.Time {
+	return weekOneStart.AddDate(0, 0, 7*(week-1))
+}
+
+// WeekAt is the academic week t falls in.
+func WeekAt(t time.Time) (int, error) {
+	t = t.In(Campus)
+	days := int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, Campus).Sub(weekOneStart).Hours()+12) / 24
+	if days < 0 {
+		return 0, ErrOutOfTerm
+	}
+
+	week := days/7 + 1
+	if !validWeek(week) {
+		return 0, ErrOutOfTerm
+	}
+
+	return week, nil
+}
+
+func loadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	check(err)
+	return location
+}
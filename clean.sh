@@ -1,2 +0,0 @@
-#!/usr/bin/env bash
-rm "$1/"*.html
@@ -1,2 +1,2 @@
 #!/usr/bin/env bash
-curl 'https://studentssp.wit.ie/Timetables/RoomTT.aspx' -H 'Content-Type: application/x-www-form-urlencoded' --data '__EVENTTARGET=CboLocation&__EVENTARGUMENT=&__LASTFOCUS=&__VIEWSTATE=%2FwEPDwUKMTIxNDcyNzE4OQ9kFgICAw9kFggCBQ8PZA8QFgFmFgEWAh4OUGFyYW1ldGVyVmFsdWUFASUWAWZkZAIHDw9kDxAWAmYCARYCFgIfAAUBJRYCHwAFASUWAmZmZGQCEQ8PFgIeBFRleHQFB1RUMTctMThkZAITD2QWDgIBDxAPFgIeC18hRGF0YUJvdW5kZ2QQFQgaLS0gUGxlYXNlIFNlbGVjdCBTY2hvb2wgLS0ZU2Nob29sIG9mIEFkdWx0IEVkdWNhdGlvbhJTY2hvb2wgb2YgQnVzaW5lc3MVU2Nob29sIG9mIEVuZ2luZWVyaW5nGVNjaG9vbCBvZiBIZWFsdGggU2NpZW5jZXMUU2Nob29sIG9mIEh1bWFuaXRpZXMfU2Nob29sIG9mIFNjaWVuY2UgYW5kIENvbXB1dGluZwAVCAElAkVQAlNCAlNFAkhTAlNIAlNTABQrAwhnZ2dnZ2dnZ2RkAgMPEA8WAh8CZ2QQFTISd2VlayAyICgxMS1TRVAtMTcpEndlZWsgMyAoMTgtU0VQLTE3KRJ3ZWVrIDQgKDI1LVNFUC0xNykSd2VlayA1ICgwMi1PQ1QtMTcpEndlZWsgNiAoMDktT0NULTE3KRJ3ZWVrIDcgKDE2LU9DVC0xNykSd2VlayA4ICgyMy1PQ1QtMTcpEndlZWsgOSAoMzAtT0NULTE3KRN3ZWVrIDEwICgwNi1OT1YtMTcpE3dlZWsgMTEgKDEzLU5PVi0xNykTd2VlayAxMiAoMjAtTk9WLTE3KRN3ZWVrIDEzICgyNy1OT1YtMTcpE3dlZWsgMTQgKDA0LURFQy0xNykTd2VlayAxNSAoMTEtREVDLTE3KRN3ZWVrIDE2ICgxOC1ERUMtMTcpE3dlZWsgMTcgKDI1LURFQy0xNykTd2VlayAxOCAoMDEtSkFOLTE4KRN3ZWVrIDE5ICgwOC1KQU4tMTgpE3dlZWsgMjAgKDE1LUpBTi0xOCkTd2VlayAyMSAoMjItSkFOLTE4KRN3ZWVrIDIyICgyOS1KQU4tMTgpE3dlZWsgMjMgKDA1LUZFQi0xOCkTd2VlayAyNCAoMTItRkVCLTE4KRN3ZWVrIDI1ICgxOS1GRUItMTgpE3dlZWsgMjYgKDI2LUZFQi0xOCkTd2VlayAyNyAoMDUtTUFSLTE4KRN3ZWVrIDI4ICgxMi1NQVItMTgpE3dlZWsgMjkgKDE5LU1BUi0xOCkTd2VlayAzMCAoMjYtTUFSLTE4KRN3ZWVrIDMxICgwMi1BUFItMTgpE3dlZWsgMzIgKDA5LUFQUi0xOCkTd2VlayAzMyAoMTYtQVBSLTE4KRN3ZWVrIDM0ICgyMy1BUFItMTgpE3dlZWsgMzUgKDMwLUFQUi0xOCkTd2VlayAzNiAoMDctTUFZLTE4KRN3ZWVrIDM3ICgxNC1NQVktMTgpE3dlZWsgMzggKDIxLU1BWS0xOCkTd2VlayAzOSAoMjgtTUFZLTE4KRN3ZWVrIDQwICgwNC1KVU4tMTgpE3dlZWsgNDEgKDExLUpVTi0xOCkTd2VlayA0MiAoMTgtSlVOLTE4KRN3ZWVrIDQzICgyNS1KVU4tMTgpE3dlZWsgNDQgKDAyLUpVTC0xOCkTd2VlayA0NSAoMDktSlVMLTE4KRN3ZWVrIDQ2ICgxNi1KVUwtMTgpE3dlZWsgNDcgKDIzLUpVTC0xOCkTd2VlayA0OCAoMzAtSlVMLTE4KRN3ZWVrIDQ5ICgwNi1BVUctMTgpE3dlZWsgNTAgKDEzLUFVRy0xOCkTd2VlayA1MSAoMjAtQVVHLTE4KRUyATIBMwE0ATUBNgE3ATgBOQIxMAIxMQIxMgIxMwIxNAIxNQIxNgIxNwIxOAIxOQIyMAIyMQIyMgIyMwIyNAIyNQIyNgIyNwIyOAIyOQIzMAIzMQIzMgIzMwIzNAIzNQIzNgIzNwIzOAIzOQI0MAI0MQI0MgI0MwI0NAI0NQI0NgI0NwI0OAI0OQI1MAI1MRQrAzJnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2RkAgUPEA8WAh8CZ2QQFRIYLS0gUGxlYXNlIFNlbGVjdCBEZXB0IC0tHEJBIC0gQWNjb3VudGFuY3kgJiBFY29ub21pY3MWQkcgLSBHcmFkdWF0ZSBCdXNpbmVzcx5CTSAtIE1hbmFnZW1lbnQgJiBPcmdhbmlzYXRpb24MQ0wgLSBDZW50cmFsFERBIC0gQWR1bHQgRWR1Y2F0aW9uEUVBIC0gQXJjaGl0ZWN0dXJlFkVDIC0gQnVpbHQgRW52aXJvbm1lbnQbRUUgLSBFbmdpbmVlcmluZyBUZWNobm9sb2d5EkVUIC0gVHJhZGUgU3R1ZGllcxFIQSAtIEFwcGxpZWQgQXJ0cx9IQyAtIENyZWF0aXZlICYgUGVyZm9ybWluZyBBcnRzF0hMIC0gTGFuZ3VhZ2VzLCBUb3VyaXNtFkhNIC0gUFQgLSBNdXNpYyBTY2hvb2wMU0MgLSBTY2llbmNlGFNQIC0gQ29tcHV0aW5nIGFuZCBNYXRocy1YRSAtIERlcGFydG1lbnQgb2YgU3BvcnQgYW5kIEV4ZXJjaXNlIFNjaWVuY2UMWE4gLSBOdXJzaW5nFRIBJSA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCRCAyRUVDMkY0NjZEN0REMUU3RkY2NDQ3Q0JCQTQ4QzlFNyA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCRiA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRUQzQSA0MUEyQkNDRkY2OUFGOTNDMzI4NzcwODYwOTFCMDlGOCAwRjU1MjJCMDEwMENGOUZCRTNGMDg2NzQyNUVCMzVBQSA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCQyA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCQiA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCQSA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCNyA5QUYzRDExNUFBMkEzMDlGNkE2QzM1OTVFMEJEMjlENCA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCOCAyRUVDMkY0NjZEN0REMUU3RkY2NDQ3Q0JCQTQ4QzNDRSA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCNiA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVDMCA2QUJFMkVFNzAzQzA2MDMyMkVDODIxRjFDMkFBMTY3RSA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCORQrAxJnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dkZAIHDxAPFgIfAmdkEBUTBTA5OjE1BTEwOjE1BTExOjE1BTEyOjE1BTEzOjE1BTE0OjE1BTE1OjE1BTE2OjE1BTE3OjE1BTE4OjAwBTE4OjE1BTE4OjMwBTE5OjAwBTE5OjE1BTE5OjMwBTIwOjAwBTIwOjE1BTIwOjMwBTIxOjAwFRMBMQEyATMBNAE1ATYBNwE4ATkCMTACMTECMTICMTMCMTQCMTUCMTYCMTcCMTgCMTkUKwMTZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2RkAgkPEA8WAh8CZ2QQFRQFMTc6MTUFMDk6MTUFMTA6MTUFMTE6MTUFMTI6MTUFMTM6MTUFMTQ6MTUFMTU6MTUFMTY6MTUFMTc6MTUFMTg6MDAFMTg6MTUFMTg6MzAFMTk6MDAFMTk6MTUFMTk6MzAFMjA6MDAFMjA6MTUFMjA6MzAFMjE6MDAVFAE4ATEBMgEzATQBNQE2ATcBOAE5AjEwAjExAjEyAjEzAjE0AjE1AjE2AjE3AjE4AjE5FCsDFGdnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZGQCCw8QDxYCHwJnZBAV%2FwIEICAgICAjU1BMVVMxNTc3RDkgLSBHIE9ubGluZSBEZWxpdmVyeSEtUm9vbV9GbGV4aUhyIC0gRmxleGkgSG91ciBPZmZpY2UuMC4xIFN0dWRpbyAtICBHcmFuYXJ5IEdyb3VuZCBmbG9vciAoQTUgU3R1ZGlvKRExMjEgLSBNZWRpY2FsIExhYhExMjIgLSBNZWRpY2FsIExhYhIxMjMgLSBTZW5zb3J5IFJvb20RMTI3IC0gTWVkaWNhbCBMYWIRMTI4IC0gTWVkaWNhbCBMYWIWMjIzIC0gTGVjdHVyZS9UdXRvcmlhbBYyMjQgLSBMZWN0dXJlL1R1dG9yaWFsFjIyNSAtIExlY3R1cmUvVHV0b3JpYWwWMjI2IC0gTGVjdHVyZS9UdXRvcmlhbBYyMjcgLSBMZWN0dXJlL1R1dG9yaWFsFjIyOCAtIExlY3R1cmUvVHV0b3JpYWwWMjI5IC0gTGVjdHVyZS9UdXRvcmlhbBIyMzAgLSBDb21wdXRlciBMYWIRM0QgLSBQcm9qZWN0IExhYnMdQSZCVDEgU3R1ZGlvIC0gRHJhd2luZyBTdHVkaW8dQSZCVDIgU1RVRElPIC0gRHJhd2luZyBTdHVkaW8dQSZCVDMgU1RVRElPIC0gRHJhd2luZyBTdHVkaW8dQSZCVDQgU3R1ZGlvIC0gRHJhd2luZyBTdHVkaW8XQTAwMiAtIExpYnJhcnkgQ29tcCBMYWIUQTAwNSAtIExpYnJhcnkgU3R1ZHkXQTAwNiAtIExlY3R1cmUvVHV0b3JpYWwaQTEgU3R1ZGlvIC0gRHJhd2luZyBTdHVkaW8XQTEwMSAtIENvbXB1dGVyIE1hYyBMYWIUQTEwMiAtIERlc2lnbiBTdHVkaW8MQTEwMyAtIE11c2ljEUExMDQgLSBNdXNpYyBSb29tDEExMDUgLSBNdXNpYwxBMTA2IC0gTXVzaWMMQTEwNyAtIE11c2ljDEExMDggLSBNdXNpYwxBMTA5IC0gTXVzaWMMQTExMCAtIE11c2ljDEExMTEgLSBNdXNpYwxBMTEyIC0gTXVzaWMMQTExMyAtIE11c2ljD0ExMTQgLSBDb21wdXRlchdBMTE1IC0gTGVjdHVyZS9UdXRvcmlhbBRBMTE2IC0gRGVzaWduIFN0dWRpbxRBMTE3IC0gRGVzaWduIFN0dWRpbxpBMiBTdHVkaW8gLSBEcmF3aW5nIFN0dWRpbxFBMjAxQSAtIEFuaW1hdGlvbhJBMjAxQiAtIEFydCBTdHVkaW8MQTIwMiAtIFZpZGVvDEEyMDMgLSBNdXNpYwxBMjA0IC0gTXVzaWMMQTIwNSAtIE11c2ljDEEyMDYgLSBNdXNpYwxBMjA3IC0gTXVzaWMMQTIwOCAtIE11c2ljDEEyMDkgLSBNdXNpYwxBMjEwIC0gTXVzaWMRQTIxMiAtIEFydCBTdHVkaW8RQTIxMyAtIEFydCBTdHVkaW8RQTIxNCAtIEFydCBTdHVkaW8aQTMgU3R1ZGlvIC0gRHJhd2luZyBTdHVkaW8aQTQgU3R1ZGlvIC0gRHJhd2luZyBTdHVkaW8ZQUFURU1QIC0gTGVjdHVyZS9UdXRvcmlhbBNBRzAzIC0gUmVzZWFyY2ggTGFiD0FHMDQgLSBSZXNlYXJjaA9BRzA3IC0gUmVzZWFyY2gTQUcwOCAtIFJlc2VhcmNoIExhYg9BRzA5IC0gUmVzZWFyY2gPQUcxMCAtIFJlc2VhcmNoFEFHMTQgLSBSZXNlYXJjaCBTZWFtFEFHMTUgLSBSZXNlYXJjaCBTZWFtF0FHMTYgLSBMZWN0dXJlL1R1dG9yaWFsFkFHMTcgLSBQaHN5Y2hvbG9neSBMYWIXQUcxOCAtIExlY3R1cmUvVHV0b3JpYWwXQUcyMCAtIExlY3R1cmUvVHV0b3JpYWwHQUcyMSAtICNBRzI1IC0gRWxlY3RyaWNhbCBTZWN1cnRpeSBXb3Jrc2hvcA9BRzI2IC0gQnVpbGRpbmcaQUcyNyAtIEVsZWN0cmljYWwgV29ya3Nob3AmQUczMSAtIE1lY2hhbmljYWwgRW5naW5lZXJpbmcgV29ya3Nob3ANQUczMiAtIEdhcmFnZRdBRzMzIC0gTGVjdHVyZS9UdXRvcmlhbBdBRzM0IC0gTGVjdHVyZS9UdXRvcmlhbBZBTDEgLSBMZWN0dXJlL1R1dG9yaWFsEUFMMTEgLSBNdXNpYyBSb29tFkFMMiAtIExlY3R1cmUvVHV0b3JpYWwWQUwzIC0gTGVjdHVyZS9UdXRvcmlhbA5BTFRBUiAtIFN0dWRpbxhBVDEwMyAtIExlY3R1cmUvVHV0b3JpYWwYQVQxMDQgLSBMZWN0dXJlL1R1dG9yaWFsGEFUMTA1IC0gTGVjdHVyZS9UdXRvcmlhbBZBVDEwNiAtIERyYXdpbmcgU3R1ZGlvFkFUMTA3IC0gRWxlY3RyaWNhbCBMYWIQQVQxMDggLSBDb21wdXRlchZBVDEwOSAtIEVsZWN0cmljYWwgTGFiGEFUMTEwIC0gTGVjdHVyZS9UdXRvcmlhbBhBVDExMSAtIExlY3R1cmUvVHV0b3JpYWwYQVQxMTIgLSBMZWN0dXJlL1R1dG9yaWFsEEFUMTIxIC0gQ29tcHV0ZXIYQVQxMjYgLSBMZWN0dXJlL1R1dG9yaWFsDkFUMTMwIC0gT2ZmaWNlMUFkdWx0IExpdGVyYWN5IE91dHJlYWNoIC0gQWR1bHQgTGl0ZXJhY3kgT3V0cmVhY2gOQjAxIC0gUmVzZWFyY2gOQjAyIC0gUmVzZWFyY2gOQjAzIC0gUmVzZWFyY2gWQjA3IC0gTGVjdHVyZS9UdXRvcmlhbA1CMDggLSBCaW9sb2d5DUIwOSAtIEJpb2xvZ3kOQjA5QSAtIEJpb2xvZ3kNQjEwIC0gQmlvbG9neQlCMTEgLSBOTVIPQjEyIC0gQ2hlbWlzdHJ5D0IxMyAtIENoZW1pc3RyeQ9CMTUgLSBDaGVtaXN0cnkPQjE2IC0gQ2hlbWlzdHJ5DUIxOCAtIFBoeXNpY3MNQjE5IC0gUGh5c2ljcwlCMjAgLSBTZW0OQjIxIC0gUmVzZWFyY2gQQjIzIC0gSW5zdHJ1bWVudBFCMjkgLSBQaWxvdCBQbGFudBFCQUtFUlkgLSBDb21wdXRlcglCQVIgLSBCYXIuQkVUTCAtIEJ1aWxkIEVudmlyb25tZW50IFRlY2hub2xvZ3kgTGFib3JhdG9yeRZCTDEgLSBMZWN0dXJlL1R1dG9yaWFsF0JMMTQgLSBMZWN0dXJlL1R1dG9yaWFsFkJMMiAtIExlY3R1cmUvVHV0b3JpYWwSQkwzIC0gQ29tcHV0ZXIgTGFiFkJMNCAtIExlY3R1cmUvVHV0b3JpYWwPQkw5IC0gQm9hcmRyb29tD0JXMSAtIEJ1aWxkaW5nIB9Cb3QgR2FyZGVucyAtIEJvdGFuaWNhbCBHYXJkZW5zF0MwMDEgLSBMZWN0dXJlL1R1dG9yaWFsF0MwMDIgLSBMZWN0dXJlL1R1dG9yaWFsF0MwMDMgLSBMZWN0dXJlL1R1dG9yaWFsF0MwMDQgLSBMZWN0dXJlL1R1dG9yaWFsF0MwMDUgLSBMZWN0dXJlL1R1dG9yaWFsF0MwMTQgLSBMZWN0dXJlL1R1dG9yaWFsDEMwNyAtIE9mZmljZQlDMTEgLSBDQUQXQzExMSAtIExlY3R1cmUvVHV0b3JpYWwXQzExNSAtIExlY3R1cmUvVHV0b3JpYWwSQzE1IC0gRGVtbyBLaXRjaGVuFUMxOSAtIFByb2R1Y3QgS2l0Y2hlbhZDMjAgLSBUcmFpbmluZyBLaXRjaGVuF0MyMDQgLSBMZWN0dXJlL1R1dG9yaWFsE0MyMDYgLSBDb21wdXRlciBMYWITQzIxMiAtIENvbXB1dGVyIExhYhJDMjMgLSBDb25zdHJ1Y3Rpb24PQzI0IC0gTWVjaGFuaWNzEEMyNSAtIFRlY2hub2xvZ3kWQzI2IC0gTGVjdHVyZS9UdXRvcmlhbBJDMjcgLSBSZXNlYXJjaCBMYWIGQzI4IC0gFkMyOSAtIExlY3R1cmUvVHV0b3JpYWwiQzMwIC0gQ29uc3RydWN0aW9uIE1hbmFnZW1lbnQgUm9vbRZDMzEgLSBMZWN0dXJlL1R1dG9yaWFsG0MzMiAtIFRlY2hub2xvZ3kgJiBDb21wdXRlchFDMzMgLSBFbGVjdHJvbmljcxFDMzQgLSBFbGVjdHJvbmljcxZDMzUgLSBMZWN0dXJlL1R1dG9yaWFsEkMzOCAtIFNjaWVuY2UgQ29tcBFDMzkgLSBFbGVjdHJvbmljcxJDMzlBIC0gRWxlY3Ryb25pY3MWQzQyIC0gTGVjdHVyZS9UdXRvcmlhbBJDNDcgLSBTdGFmZiBPZmZpY2URQzQ4IC0gRWxlY3Ryb25pY3MSQzQ4QSAtIEVsZWN0cm9uaWNzGEM1MSAtIFBNQlJDIFJlc2VhcmNoIExhYhNDRVJBTUlDUyAtIGNlcmFtaWNzEUNHTiBBVyAgLSBDR04gQVcgI0NHTiBEQU5DRSBTVFVESU8gLSBDR04gREFOQ0UgU1RVRElPEUNHTiBHWU0gLSBDR04gR1lNIUNHTiBTUE9SVFMgSEFMTCAtIENnbiBTcG9ydHMgSGFsbCpDR04gVFJBSU5JTkcgUk9PTSAxIC0gQ2duIFRyYWluaW5nIHJvb20gIDElQ0dOIFRSQUlOSU5HIFJPT00gMiAtIFRyYWluaW5nIHJvb20gMiVDR04gVFJBSU5JTkcgUk9PTSAzIC0gVHJhaW5pbmcgUm9vbSAzD0NIQVBFTCAtIENoYXBlbBZDTDEgLSBMZWN0dXJlL1R1dG9yaWFsFkNMMiAtIExlY3R1cmUvVHV0b3JpYWwWQ0wzIC0gTGVjdHVyZS9UdXRvcmlhbBJDTDQgLSBDb21wdXRlciBMYWIVQ291cnR5YXJkIC0gQ291cnR5YXJkEUQwMSAtIFBvc3QgLSBHcmFkEkQwMiAtIENvbXB1dGVyIExhYhBEMDQgLSBNdWx0aW1lZGlhEEQwNSAtIE11bHRpbWVkaWEORDA4IC0gUmVzZWFyY2gWRDExIC0gTGVjdHVyZS9UdXRvcmlhbA9EMTIgLSBFeGFtIFJvb20MRDI1IC0gb2ZmaWNlEUQyOEEgLSBSZXRhaWwgTGFiDURBTkNFIC0gRGFuY2URREFSSyBST09NIC0gUGhvdG8WRTAzIC0gTGVjdHVyZS9UdXRvcmlhbBZFMDQgLSBMZWN0dXJlL1R1dG9yaWFsFkUwNyAtIExlY3R1cmUvVHV0b3JpYWwWRTEzIC0gTGVjdHVyZS9UdXRvcmlhbBZFMTUgLSBMZWN0dXJlL1R1dG9yaWFsF0UxOUEgLSBMZWN0dXJlL1R1dG9yaWFsF0UxOUIgLSBMZWN0dXJlL1R1dG9yaWFsHUVUUkMxIC0gUmVzZWFyY2ggLyBQbmV1bWF0aWNzHUVUUkMyIC0gUmVzZWFyY2ggLyBQbmV1bWF0aWNzHUVUUkMzIC0gUmVzZWFyY2ggLyBNZWNoYW5pY2FsK0VubmlzY29ydGh5IE91dHJlYWNoIC0gRW5uaXNjb3J0aHkgT3V0cmVhY2gWRjAxIC0gTGVjdHVyZS9UdXRvcmlhbBZGMDIgLSBMZWN0dXJlL1R1dG9yaWFsFkYwMyAtIExlY3R1cmUvVHV0b3JpYWwWRjA0IC0gTGVjdHVyZS9UdXRvcmlhbBZGMDYgLSBMZWN0dXJlL1R1dG9yaWFsFkYwNyAtIExlY3R1cmUvVHV0b3JpYWwWRjA5IC0gTGVjdHVyZS9UdXRvcmlhbBZGMjAgLSBMZWN0dXJlL1R1dG9yaWFsFkYyMyAtIExlY3R1cmUvVHV0b3JpYWwWRjI2IC0gTGVjdHVyZS9UdXRvcmlhbBZGMjcgLSBMZWN0dXJlL1R1dG9yaWFsFkYyOCAtIExlY3R1cmUvVHV0b3JpYWwXRjI4QSAtIExlY3R1cmUvVHV0b3JpYWwWRjI5IC0gTGVjdHVyZS9UdXRvcmlhbBZGMzAgLSBMZWN0dXJlL1R1dG9yaWFsIkZURyBIQSBESVNTIFRCQSAtIExlY3R1cmUvVHV0b3JpYWwYRlRHMTAgLSBMZWN0dXJlL1R1dG9yaWFsGEZURzExIC0gTGVjdHVyZS9UdXRvcmlhbBhGVEcxMiAtIExlY3R1cmUvVHV0b3JpYWwYRlRHMTMgLSBMZWN0dXJlL1R1dG9yaWFsGEZURzE0IC0gTGVjdHVyZS9UdXRvcmlhbBRGVEcxNSAtIENvbnN0cnVjdGlvbhhGVEcxOCAtIExlY3R1cmUvVHV0b3JpYWwYRlRHMTkgLSBMZWN0dXJlL1R1dG9yaWFsGEZURzIwIC0gTGVjdHVyZS9UdXRvcmlhbBZGVEcyMSAtIFBzeWNob2xvZ3kgTGFiGEZURzIyIC0gTGVjdHVyZS9UdXRvcmlhbBhGVEcyMyAtIExlY3R1cmUvVHV0b3JpYWwYRlRHMjQgLSBMZWN0dXJlL1R1dG9yaWFsGEZURzI1IC0gTGVjdHVyZS9UdXRvcmlhbBhGVEcyOSAtIExlY3R1cmUvVHV0b3JpYWwlRyBPbmxpbmUgRGVsaXZlcnkgLSBHIE9ubGluZSBEZWxpdmVyeRZHMTIgLSBMZWN0dXJlL1R1dG9yaWFsFkcxNyAtIExlY3R1cmUvVHV0b3JpYWwWRzE4IC0gTGVjdHVyZS9UdXRvcmlhbBZHMTkgLSBMZWN0dXJlL1R1dG9yaWFsFkcyMCAtIExlY3R1cmUvVHV0b3JpYWweR1IgQVQxIFN0dWRpbyAtIERyYXdpbmcgU3R1ZGlvHkdSIEFUMiBTdHVkaW8gLSBEcmF3aW5nIFN0dWRpbx9HUiBBVDMgU3R1ZGlvIC0gQ29tcHV0ZXIgU3R1ZGlvH0dSIEFUNCBTdHVkaW8gLSBDb21wdXRlciBTdHVkaW8iR1IgTGVjdHVyZSBIYWxsIC0gTGVjdHVyZS9UdXRvcmlhbBZHU0sgLSBUcmFpbmluZyBLaXRjaGVuCUdZTSAtIEdZTRhIQSAwNiAtIExlY3R1cmUvVHV0b3JpYWwYSEEgMDcgLSBMZWN0dXJlL1R1dG9yaWFsGEhBIDA4IC0gTGVjdHVyZS9UdXRvcmlhbBhIQSAxNyAtIExlY3R1cmUvVHV0b3JpYWwYSEEgMTggLSBMZWN0dXJlL1R1dG9yaWFsFUhBIDIwIC0gRGVzaWduIFN0dWRpbxhIQSAyMSAtIExlY3R1cmUvVHV0b3JpYWwQSEEgMjIgLSBDb21wdXRlch5IQSBPRkZJQ0UgLSBIQSBMZWN0dXJlciBPZmZpY2UTSEEyMyAtIG1lZXRpbmcgcm9vbR5IQyBPRkZJQ0UgLSBIQyBMZWN0dXJlciBPZmZpY2UVSEwgT0ZGSUNFIC0gSEwgT2ZmaWNlG0lUMTAxIC0gQ29tcHV0ZXIgTXVsdGltZWRpYRhJVDEwMiAtIENvbXB1dGVyIE1hYyBMYWIgSVQxMDMgLSBDb21wdXRlciBHYW1lcyBGb3JlbnNpY3MUSVQxMTggLSBDb21wdXRlciBMYWIUSVQxMTkgLSBDb21wdXRlciBMYWIUSVQxMjAgLSBDb21wdXRlciBMYWIUSVQyMDEgLSBDb21wdXRlciBMYWIQSVQyMDIgLSBDb21wdXRlchBJVDIwMyAtIENvbXB1dGVyFElUMjIwIC0gQ29tcHV0ZXIgTGFiFElUMjIxIC0gQ29tcHV0ZXIgTGFiFElUMjIyIC0gQ29tcHV0ZXIgTGFiFElURzAxIC0gQ29tcHV0ZXIgTGFiFElURzAyIC0gQ29tcHV0ZXIgTGFiFElURzAzIC0gQ29tcHV0ZXIgTGFiFElURzE3IC0gQ29tcHV0ZXIgTGFiFElURzE4IC0gQ29tcHV0ZXIgTGFiFElURzE5IC0gQ29tcHV0ZXIgTGFiHEtJTERBTFRPTiAtIExlY3R1cmUvVHV0b3JpYWwVTGlmZSBEcmF3IC0gTGlmZSBEcmF3FE1PT1JFUEFSSyAtIEZPT0QgTEFCDU5QTCAtIFBoeXNpY3MNUEhPVE8gLSBQaG90bxJQTDEgLSBQbHVtYmluZyBMYWIUUE9PTCAtIFN3aW1taW5nIFBvb2wcUE9TVC1HUkFEIC0gTGVjdHVyZS9UdXRvcmlhbBdQVzEgLSBQbHVtYmluZyBXb3Jrc2hvcBdQVzIgLSBQbHVtYmluZyBXb3Jrc2hvcBdQVzMgLSBQbHVtYmluZyBXb3Jrc2hvcBBSQjEwNCAtIFJlc2VhcmNoEFJCMTA1IC0gUmVzZWFyY2gHUkVTVCAtIB1SU0NUUkFDSyAtIFJTQyBBdGxldGljcyBUcmFjax5TQyBPRkZJQ0UgLSBTQyBMZWN0dXJlciBPZmZpY2UeU0ggT0ZGSUNFIC0gU0ggTGVjdHVyZXIgT2ZmaWNlFlNJVEUgVklTSVQgLSBQbGFjZW1lbnQeU04gT0ZGSUNFIC0gU04gTGVjdHVyZXIgT2ZmaWNlHlNQIE9GRklDRSAtIFNQIExlY3R1cmVyIE9mZmljZRJTUEEgLSBQcm9qZWN0IExhYnMiU1BBLU1ldGFsIC0gU3BlY2lhbCBQdXJwb3NlLSBNZXRhbCZTUEEtUGxhc3RlciAtIFNwZWNpYWwgUHVycG9zZS0gUGxhc3RlciJTUEEtUHJpbnQgLSBTcGVjaWFsIFB1cnBvc2UtIFByaW50FlNUVURJTzEgLSBQcm9qZWN0IExhYnMWU3R1ZGlvIC0gRGVzaWduIFN0dWRpbx1UQkEgMV8gRUMgIC0gTGVjdHVyZS9UdXRvcmlhbAhUTDExNCAtIBRUTDExNiAtIENvbXB1dGVyIExhYhBUTDEyMCAtIENvbXB1dGVyEFRMMTIxIC0gQ29tcHV0ZXIQVEwxMjggLSBDb21wdXRlchBUTDEyOSAtIENvbXB1dGVyFVRMMTMzIEdZTSAtIFRMMTMzIEdZTSFUTDE0OCBDbGluaWNhbCBsYWIgLSBDbGluaWNhbCBMYWIoVEwxNDkgSFBMIC0gSHVtYW4gUGVyZm9ybWFuY2UgTGFiICBMYWIgMShUTDE1NSBIUEwgLSBIdW1hbiBQZXJmb3JtYW5jZSBMYWIgIExhYiAyGFRMMTU3IC0gTGVjdHVyZS9UdXRvcmlhbBhUTDE1OCAtIExlY3R1cmUvVHV0b3JpYWwYVEwxNTkgLSBMZWN0dXJlL1R1dG9yaWFsGFRMMjIxIC0gTGVjdHVyZS9UdXRvcmlhbBhUTDIyNSAtIExlY3R1cmUvVHV0b3JpYWwYVEwyMjggLSBMZWN0dXJlL1R1dG9yaWFsGFRMMjM1IC0gTGVjdHVyZS9UdXRvcmlhbBRUTDIzNiAtIENvbXB1dGVyIExhYhRUTDIzOCAtIExlY3R1cmUgUm9vbRtUTDI0NChBKSAtIExlY3R1cmUvVHV0b3JpYWwbVEwyNDQoQikgLSBMZWN0dXJlL1R1dG9yaWFsGFRMMjQ1IC0gTGVjdHVyZS9UdXRvcmlhbBhUTDI0OSAtIExlY3R1cmUvVHV0b3JpYWwYVEwyNTAgLSBMZWN0dXJlL1R1dG9yaWFsGFRMMjUxIC0gTGVjdHVyZS9UdXRvcmlhbBhUTDI1MiAtIExlY3R1cmUvVHV0b3JpYWwUVExHMDMgLSBEZW1vIEtpdGNoZW4LVExHMDkgLSBCQVILVExHMTAgLSBCQVISVExHMjEgLSBBdWRpdG9yaXVtHlRMRzIyIC0gRmluZSBEaW5pbmcgUmVzdGF1cmFudBxUTEcyNyAtIFByb2R1Y3Rpb24gS2l0Y2hlbiAxHFRMRzI4IC0gUHJvZHVjdGlvbiBLaXRjaGVuIDIOVExHMjkgLSBCSVNUUk8aVExHMzQgLSBUcmFpbmluZyBLaXRjaGVuIDIaVExHMzUgLSBUcmFpbmluZyBLaXRjaGVuIDEWVExHNDggLSBMYXJkZXIgS2l0Y2hlbhZUTEc1MSAtIFBhc3RyeSBLaXRjaGVuGlRMRzYwIC0gVHJhaW5pbmcgS2l0Y2hlbiAzGVRMRzYyIC0gSW5kdWN0aW9uIEtpdGNoZW4UVzAyIC0gRm9ydWxhdGlvbiBMYWIRVzAzIC0gUGh5c2ljcyBMYWIQVzA0IC0gT3B0aWNzIGxhYhFXMDUgLSBQaHlzaWNzIExhYhFXMDYgLSBQaHlzaWNzIExhYglXMDcgLSBMYWINVzA4IC0gTmV3IExhYhZXMDkgLSBMZWN0dXJlL1R1dG9yaWFsFlcxMCAtIExlY3R1cmUvVHV0b3JpYWwWVzExIC0gTGVjdHVyZS9UdXRpb3JhbA9XMTIgLSBTdXJ2ZXlpbmcWVzEzIC0gTGVjdHVyZS9UdXRvcmlhbBZXMTQgLSBMZWN0dXJlL1R1dG9yaWFsDlcxOCAtIEZvcmVzdHJ5DlcxOSAtIENvbXB1dGVyC1cyMCAtIENpdmlsG1cyMSAtIEJ1aWx0IEVudmlyb25tZW50IExhYhtXRUlHSFRTIFJPT00gLSBXRUlHSFRTIFJPT00ZWEVfVEJBIC0gTGVjdHVyZS9UdXRvcmlhbCVfQXRobG9uZSBPdXRyZWFjaCAtIF9BdGhsb25lIE91dHJlYWNoGl9CUiBUQkEgLSBMZWN0dXJlL1R1dG9yaWFsHV9DUyBPZmZpY2UgLSBMZWN0dXJlL1R1dG9yaWFsGl9HUyBUQkEgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtQkEgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtQkcgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtQk0gLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtRUEgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtRUMgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtRUUgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtU0MgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtU1AgLSBMZWN0dXJlL1R1dG9yaWFsHV9NQyBPRkZJQ0UgLSBMZWN0dXJlL1R1dG9yaWFsHF9NQyBPZmZpY2UgWE4gLSBEaXNzZXJ0YXRpb24aX01DIFRCQSAtIExlY3R1cmUvVHV0b3JpYWwnX1N0IFBhdHMgT3V0cmVhY2ggIC0gX1N0IFBhdHMgT3V0cmVhY2ggGl9UQkEvQkUgLSBMZWN0dXJlL1R1dG9yaWFsJV9UQkEvQkUgQ29sbGVnZSBTdCAtIExlY3R1cmUvVHV0b3JpYWwaX1RCQS9EQSAtIExlY3R1cmUvVHV0b3JpYWwlX1RodXJsZXMgT3V0cmVhY2ggLSBfVGh1cmxlcyBPdXRyZWFjaBX%2FAgIgIAwjU1BMVVMxNTc3RDkNLVJvb21fRmxleGlIcgowLjEgU3R1ZGlvAzEyMQMxMjIDMTIzAzEyNwMxMjgDMjIzAzIyNAMyMjUDMjI2AzIyNwMyMjgDMjI5AzIzMAIzRAxBJkJUMSBTdHVkaW8MQSZCVDIgU1RVRElPDEEmQlQzIFNUVURJTwxBJkJUNCBTdHVkaW8EQTAwMgRBMDA1BEEwMDYJQTEgU3R1ZGlvBEExMDEEQTEwMgRBMTAzBEExMDQEQTEwNQRBMTA2BEExMDcEQTEwOARBMTA5BEExMTAEQTExMQRBMTEyBEExMTMEQTExNARBMTE1BEExMTYEQTExNwlBMiBTdHVkaW8FQTIwMUEFQTIwMUIEQTIwMgRBMjAzBEEyMDQEQTIwNQRBMjA2BEEyMDcEQTIwOARBMjA5BEEyMTAEQTIxMgRBMjEzBEEyMTQJQTMgU3R1ZGlvCUE0IFN0dWRpbwZBQVRFTVAEQUcwMwRBRzA0BEFHMDcEQUcwOARBRzA5BEFHMTAEQUcxNARBRzE1BEFHMTYEQUcxNwRBRzE4BEFHMjAEQUcyMQRBRzI1BEFHMjYEQUcyNwRBRzMxBEFHMzIEQUczMwRBRzM0A0FMMQRBTDExA0FMMgNBTDMFQUxUQVIFQVQxMDMFQVQxMDQFQVQxMDUFQVQxMDYFQVQxMDcFQVQxMDgFQVQxMDkFQVQxMTAFQVQxMTEFQVQxMTIFQVQxMjEFQVQxMjYFQVQxMzAXQWR1bHQgTGl0ZXJhY3kgT3V0cmVhY2gDQjAxA0IwMgNCMDMDQjA3A0IwOANCMDkEQjA5QQNCMTADQjExA0IxMgNCMTMDQjE1A0IxNgNCMTgDQjE5A0IyMANCMjEDQjIzA0IyOQZCQUtFUlkDQkFSBEJFVEwDQkwxBEJMMTQDQkwyA0JMMwNCTDQDQkw5A0JXMQtCb3QgR2FyZGVucwRDMDAxBEMwMDIEQzAwMwRDMDA0BEMwMDUEQzAxNANDMDcDQzExBEMxMTEEQzExNQNDMTUDQzE5A0MyMARDMjA0BEMyMDYEQzIxMgNDMjMDQzI0A0MyNQNDMjYDQzI3A0MyOANDMjkDQzMwA0MzMQNDMzIDQzMzA0MzNANDMzUDQzM4A0MzOQRDMzlBA0M0MgNDNDcDQzQ4BEM0OEEDQzUxCENFUkFNSUNTB0NHTiBBVyAQQ0dOIERBTkNFIFNUVURJTwdDR04gR1lND0NHTiBTUE9SVFMgSEFMTBNDR04gVFJBSU5JTkcgUk9PTSAxE0NHTiBUUkFJTklORyBST09NIDITQ0dOIFRSQUlOSU5HIFJPT00gMwZDSEFQRUwDQ0wxA0NMMgNDTDMDQ0w0CUNvdXJ0eWFyZANEMDEDRDAyA0QwNANEMDUDRDA4A0QxMQNEMTIDRDI1BEQyOEEFREFOQ0UJREFSSyBST09NA0UwMwNFMDQDRTA3A0UxMwNFMTUERTE5QQRFMTlCBUVUUkMxBUVUUkMyBUVUUkMzFEVubmlzY29ydGh5IE91dHJlYWNoA0YwMQNGMDIDRjAzA0YwNANGMDYDRjA3A0YwOQNGMjADRjIzA0YyNgNGMjcDRjI4BEYyOEEDRjI5A0YzMA9GVEcgSEEgRElTUyBUQkEFRlRHMTAFRlRHMTEFRlRHMTIFRlRHMTMFRlRHMTQFRlRHMTUFRlRHMTgFRlRHMTkFRlRHMjAFRlRHMjEFRlRHMjIFRlRHMjMFRlRHMjQFRlRHMjUFRlRHMjkRRyBPbmxpbmUgRGVsaXZlcnkDRzEyA0cxNwNHMTgDRzE5A0cyMA1HUiBBVDEgU3R1ZGlvDUdSIEFUMiBTdHVkaW8NR1IgQVQzIFN0dWRpbw1HUiBBVDQgU3R1ZGlvD0dSIExlY3R1cmUgSGFsbANHU0sDR1lNBUhBIDA2BUhBIDA3BUhBIDA4BUhBIDE3BUhBIDE4BUhBIDIwBUhBIDIxBUhBIDIyCUhBIE9GRklDRQRIQTIzCUhDIE9GRklDRQlITCBPRkZJQ0UFSVQxMDEFSVQxMDIFSVQxMDMFSVQxMTgFSVQxMTkFSVQxMjAFSVQyMDEFSVQyMDIFSVQyMDMFSVQyMjAFSVQyMjEFSVQyMjIFSVRHMDEFSVRHMDIFSVRHMDMFSVRHMTcFSVRHMTgFSVRHMTkJS0lMREFMVE9OCUxpZmUgRHJhdwlNT09SRVBBUksDTlBMBVBIT1RPA1BMMQRQT09MCVBPU1QtR1JBRANQVzEDUFcyA1BXMwVSQjEwNAVSQjEwNQRSRVNUCFJTQ1RSQUNLCVNDIE9GRklDRQlTSCBPRkZJQ0UKU0lURSBWSVNJVAlTTiBPRkZJQ0UJU1AgT0ZGSUNFA1NQQQlTUEEtTWV0YWwLU1BBLVBsYXN0ZXIJU1BBLVByaW50B1NUVURJTzEGU3R1ZGlvClRCQSAxXyBFQyAFVEwxMTQFVEwxMTYFVEwxMjAFVEwxMjEFVEwxMjgFVEwxMjkJVEwxMzMgR1lNElRMMTQ4IENsaW5pY2FsIGxhYglUTDE0OSBIUEwJVEwxNTUgSFBMBVRMMTU3BVRMMTU4BVRMMTU5BVRMMjIxBVRMMjI1BVRMMjI4BVRMMjM1BVRMMjM2BVRMMjM4CFRMMjQ0KEEpCFRMMjQ0KEIpBVRMMjQ1BVRMMjQ5BVRMMjUwBVRMMjUxBVRMMjUyBVRMRzAzBVRMRzA5BVRMRzEwBVRMRzIxBVRMRzIyBVRMRzI3BVRMRzI4BVRMRzI5BVRMRzM0BVRMRzM1BVRMRzQ4BVRMRzUxBVRMRzYwBVRMRzYyA1cwMgNXMDMDVzA0A1cwNQNXMDYDVzA3A1cwOANXMDkDVzEwA1cxMQNXMTIDVzEzA1cxNANXMTgDVzE5A1cyMANXMjEMV0VJR0hUUyBST09NBlhFX1RCQRFfQXRobG9uZSBPdXRyZWFjaAdfQlIgVEJBCl9DUyBPZmZpY2UHX0dTIFRCQRBfTUMgQUEgT2ZmaWNlLUJBEF9NQyBBQSBPZmZpY2UtQkcQX01DIEFBIE9mZmljZS1CTRBfTUMgQUEgT2ZmaWNlLUVBEF9NQyBBQSBPZmZpY2UtRUMQX01DIEFBIE9mZmljZS1FRRBfTUMgQUEgT2ZmaWNlLVNDEF9NQyBBQSBPZmZpY2UtU1AKX01DIE9GRklDRQ1fTUMgT2ZmaWNlIFhOB19NQyBUQkESX1N0IFBhdHMgT3V0cmVhY2ggB19UQkEvQkUSX1RCQS9CRSBDb2xsZWdlIFN0B19UQkEvREERX1RodXJsZXMgT3V0cmVhY2gUKwP%2FAmdnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZGQCDQ8PFgIfAQUJMzgyIFJvb21zZGRkVxCgDm3c35hDwZ5EEzNCmf6n0t0%3D&__VIEWSTATEGENERATOR=B2F77E7E&__EVENTVALIDATION=%2FwEW%2FQMCibm6BAK0o6DKAgK63%2FvACALf2pXeBQKktL%2BwCQKEtf%2BuCQKytbeuCQKytYOuCQK3tcuuCQKytd%2BuCQKytcuuCQLf2pXeBQLIpruTAQLGyZH9DQLFyZH9DQLEyZH9DQLDyZH9DQLCyZH9DQLByZH9DQLQyZH9DQLfyZH9DQLHydH%2BDQLHyd3%2BDQLHydn%2BDQLHyeX%2BDQLHyeH%2BDQLHye3%2BDQLHyen%2BDQLHyfX%2BDQLHybH9DQLHyb39DQLGydH%2BDQLGyd3%2BDQLGydn%2BDQLGyeX%2BDQLGyeH%2BDQLGye3%2BDQLGyen%2BDQLGyfX%2BDQLGybH9DQLGyb39DQLFydH%2BDQLFyd3%2BDQLFydn%2BDQLFyeX%2BDQLFyeH%2BDQLFye3%2BDQLFyen%2BDQLFyfX%2BDQLFybH9DQLFyb39DQLEydH%2BDQLEyd3%2BDQLEydn%2BDQLEyeX%2BDQLEyeH%2BDQLEye3%2BDQLEyen%2BDQLEyfX%2BDQLEybH9DQLEyb39DQLDydH%2BDQLDyd3%2BDQLxq8fqAQKKxe2EDQLzp%2F1iAp2BmrcBAvOnpdQDAsaB57sCAruQnccCAqLHkLAFAvOn2boNAvOn9cEEAvOnsfMKAvOnyegLAu2snooGAvOnrYYIAruY7awDAvOn5bcDApaJ65QIAtG775QHAvOnkXsC4qSUxAEC7cu%2Bqg0C7Mu%2Bqg0C78u%2Bqg0C7su%2Bqg0C6cu%2Bqg0C6Mu%2Bqg0C68u%2Bqg0C%2Bsu%2Bqg0C9cu%2Bqg0C7cv%2BqQ0C7cvyqQ0C7cv2qQ0C7cvKqQ0C7cvOqQ0C7cvCqQ0C7cvGqQ0C7cvaqQ0C7cueqg0C7cuSqg0C%2FpGizQEC5v6Iow0C8f6Iow0C8P6Iow0C8%2F6Iow0C8v6Iow0C9f6Iow0C9P6Iow0C9%2F6Iow0C5v6Iow0C6f6Iow0C8f7IoA0C8f7EoA0C8f7AoA0C8f78oA0C8f74oA0C8f70oA0C8f7woA0C8f7soA0C8f6oow0C8f6kow0C2teKyAYC2rigpQoCqoLO4AsC1OWL8wUCutSobgKewKmHAwKF18usCQKgvtSxDwK82dyGBAKLs5zYDAKjvtSxDwLOhPbGAgL165DsCAKQ8rLxDgK%2F2dyGBAKKs5zYDAKxmr7tAgLy%2BYvyDQLXuJC4CgL9vMC3CgKQk5qfDwKzkpqfDwLmvcC3CgKj%2Ba%2BZCAKj%2BeP3AgKj%2Bd%2BsDQLN%2BcK8AgKj%2Bb%2FkAQKj%2BauZCAKj%2BYe%2BAwKj%2BfPSCwKj%2Be%2F3AgKj%2BdusDQKj%2BbfBBQKj%2BeOoAwKj%2Bd%2FNCwLOz%2B3UDALOz9mJBwLOz7WuDgLOz6HDBgLOz534AQLOz4mdCALOz%2BWxAwLOz9HWCwLN%2BcbeAwKq%2F4aKBAKr%2F4aKBAKj%2BZeZCAKj%2BYO%2BAwKj%2Bf%2FSCwKj%2Bev3AgKj%2BcesDQKj%2BbPBBQKj%2Be%2BoAwKj%2BdvNCwLOz%2BnUDALOz7GuDgLOz63DBgLOz5n4AQLN%2Ber0AwLN%2Be6WAwLqjsLNBAKj%2Bb%2BwAwKj%2BavVCwKj%2Be%2FDBQKj%2BZurAwKj%2BffPCwLOz4XXDALOz7X6AQLOz6GfCALOz520AwLOz4npCwLOz6WwCQL11qf8AgL11pORDQL11sOkDgL11r%2FZBgL11qv%2BAQKQvbymAwKQvajbCwKQvYTwAgKQvfCUDQLOz%2FGZAwLOz62MBwL11pOvCQKQvby0DwL3jua9AgKHxrxbAozGvFsCjca8WwKCxrxbAoPGvFsCiMa8WwKJxrxbAoDGqPALAoHGqPALAobGqPALAoHGhJUCAoLGhJUCAoDG8MkKAqupw%2B4LAsnPoYcDAvTWw6wJApO97LEPAu%2FY1IYEAvqylNgMAuGZtu0CAuGZotwPAqL5g%2FINAsnPrYcDAvTWz6wJApO96LEPAqXrlOwIAsDxtvEOAvqykNgMAuGZsu0CAqL5j%2FINAsnPqYcDApO91LEPAuGZvu0CAp%2Fv%2F9EPAtTa3LgJAp6I25kCAsnP8ZkDAsnP4foBAvTWk68JApO9vLQPAr6E3tkCAuGZhuACAsnPhZoDAurQk9kCAq35s%2BQBAq35r5kIAq35m74DAq3599ILAq354%2FcCAsjPkfgBAu7Y1IYEAsjPrYcDAsjP2YkHAsjPiZ0IAqTrlOwIAuCZsu0CAq35j%2FINAq35%2F9ILAq35x6wNAsjPsa4OApK91LEPArmE9sYCAqTrkOwIAsPxsvEOAu7Y3IYEAsWynNgMAuCZvu0CAq35i%2FINAsjPlYcDAvfWt6wJApK90LEPArmE8sYCAqTrnOwIAsWymNgMAuCZuu0CAuCZltwPAvfWs6wJAu7YxIYEAsWyhNgMAsWy8LYIAsjPnYcDAqXuvs0HAszloYQGAvf9mP0BAo3nq8AMAq3ck78FAvTWw%2FIFAoeS5bICAqL5h9gJAof47tELAsjP8ZkDAvfWk68JApK9vLQPArmE3tkCAtb%2F6vcKAsvPoYcDAvbWw6wJAriEjscCAqfrqOwIAsSylNgMAsvPrYcDAvbWz6wJAqfrkOwIAsSyiLcIArOj0MYPAoSzspILApy97LEPAruEjscCAujY1IYEApy96LEPAqbrlOwIAuKZrtwPAuKZmvEGAojSo9UJAonSo9UJAo7So9UJAveCitwCAtXPoYcDAvDWw6wJAp%2B97LEPArqEjscCAszxyvEOAuvY1IYEAu2Ztu0CAq75j%2FINAp%2B91LEPAszxsvEOAuvY3IYEAsaynNgMAsayiLcIAu2Zvu0CAq75i%2FINAvCKmasJAv3xid0LAvzxid0LAv%2Fxid0LAv7xid0LAvnxid0LAvjxid0LAvXxid0LAvTxid0LAv3x5fECAvzx5fECAv%2Fx5fECAv7x5fECAvnx5fECAvjx5fECAvTx5fECAsnQirADAvPWz6wJAurY0IYEAsGykNgMAuyZsu0CAqn5j%2FINAq%2BHlu0JAq%2BHgrcIAq%2BHjq0GAqyH%2Bv4IAryAoNcJAqbokPgNAujF9JIJAuzpy4wIAu%2Fpy4wIAprpy4wIAu%2Fpp6EDAprpp6EDAuLpk8YLAu3pk8YLAuDpk8YLAsLcpqANAuLWg%2BsMAsLcvuwMAsLc2toKApnEvFsCnsS8WwKfxLxbApDEqPALApHEqPALApjEhJUCAoSr2eAGAoWr2eAGArqr2eAGAoeroboIAoSroboIAoWroboIAq%2F%2BnbgDAqz%2BnbgDAq3%2BnbgDAqn%2Bid0LAqb%2Bid0LAqf%2Bid0LAvKbgK8NAsilxP4GAsmLn98CAvr%2Bzo0DAtC1wkwC%2F8%2FxmQMCpbO78wICmPKT2g4C%2F8%2BFmgMCmtanrwkCgb3AtA8Ct8L0TAK2wvRMAuOh2d0GAuzAqqwNArngvuwMArngytoLAovUzfINArng0p4KArng6uoJAujg0%2BcOAtvZp64JArn9stkPAv%2Fsk%2FMIAtr90asMAoe8nuoFAqvgw%2BsEAvHCiPgLAvfCiPgLAv3C5JwCAvzC5JwCAsXC5JwCAsTC5JwCAobG4scJApPIgpgKApy40tADAoCkzowMAvbCuIsMAsXCuIsMAsTCuIsMApupgaIIAp%2BpgaIIAuCpgaIIAp%2Bp%2FUYCkqn9RgLgqf1GApW%2FmoMEArCGpKgKAp%2Bp6fsLAuOp6fsLApipxZACApupxZACAp6pxZACAoD8%2Fa8DAor8%2Fa8DAoP86cQLAoL8xfkCAoH8xfkCAoT8xfkCAov8xfkCAor8xfkCAof8sZ4NAob8sZ4NAov8rbMEAoL8megMAoP89YwHAoH89YwHAuPWw6wJAo697LEPArWEjscCAtDqqOwIAv%2FxyvEOAprY1IYEAvGylNgMApyZtu0CAtn4g%2FINAsTPrYcDAuPWz6wJAo696LEPArWEiscCAvGykNgMApyZsu0CAtn4j%2FINAsTPqYcDApq%2FsZwDArWHue0FAsTfmuQLAra7%2BtQHArL9u4AJAuuS2IsHAsSQx%2FcBAsSQr0ECxJCX5AwC%2F4Lo4QwC%2F4KAqA0C%2F4KY9QEC6aa%2B%2FAcC6aayuA0CwtSriQwCq4r3qwICu5OG0Q8Cn6KrywgC7bXyQQKI1fTcBAL50IKXCALD1PiADwL%2Fl8nCBQL%2B6YyoClGWqN1LA0l67JjXgpMmeyWNqELG&hProgram=&hStudentcount=&cboSchool=%25&CboWeeks=10&CboDept=%25&CboStartTime=1&CboEndTime=8&CboLocation='"$1"'&BtnRetrieve=Generate+Timetable' --compressed > "$2/$1".html
+curl 'https://studentssp.wit.ie/Timetables/RoomTT.aspx' -H 'Content-Type: application/x-www-form-urlencoded' --data '__EVENTTARGET=CboLocation&__EVENTARGUMENT=&__LASTFOCUS=&__VIEWSTATE=%2FwEPDwUKMTIxNDcyNzE4OQ9kFgICAw9kFggCBQ8PZA8QFgFmFgEWAh4OUGFyYW1ldGVyVmFsdWUFASUWAWZkZAIHDw9kDxAWAmYCARYCFgIfAAUBJRYCHwAFASUWAmZmZGQCEQ8PFgIeBFRleHQFB1RUMTctMThkZAITD2QWDgIBDxAPFgIeC18hRGF0YUJvdW5kZ2QQFQgaLS0gUGxlYXNlIFNlbGVjdCBTY2hvb2wgLS0ZU2Nob29sIG9mIEFkdWx0IEVkdWNhdGlvbhJTY2hvb2wgb2YgQnVzaW5lc3MVU2Nob29sIG9mIEVuZ2luZWVyaW5nGVNjaG9vbCBvZiBIZWFsdGggU2NpZW5jZXMUU2Nob29sIG9mIEh1bWFuaXRpZXMfU2Nob29sIG9mIFNjaWVuY2UgYW5kIENvbXB1dGluZwAVCAElAkVQAlNCAlNFAkhTAlNIAlNTABQrAwhnZ2dnZ2dnZ2RkAgMPEA8WAh8CZ2QQFTISd2VlayAyICgxMS1TRVAtMTcpEndlZWsgMyAoMTgtU0VQLTE3KRJ3ZWVrIDQgKDI1LVNFUC0xNykSd2VlayA1ICgwMi1PQ1QtMTcpEndlZWsgNiAoMDktT0NULTE3KRJ3ZWVrIDcgKDE2LU9DVC0xNykSd2VlayA4ICgyMy1PQ1QtMTcpEndlZWsgOSAoMzAtT0NULTE3KRN3ZWVrIDEwICgwNi1OT1YtMTcpE3dlZWsgMTEgKDEzLU5PVi0xNykTd2VlayAxMiAoMjAtTk9WLTE3KRN3ZWVrIDEzICgyNy1OT1YtMTcpE3dlZWsgMTQgKDA0LURFQy0xNykTd2VlayAxNSAoMTEtREVDLTE3KRN3ZWVrIDE2ICgxOC1ERUMtMTcpE3dlZWsgMTcgKDI1LURFQy0xNykTd2VlayAxOCAoMDEtSkFOLTE4KRN3ZWVrIDE5ICgwOC1KQU4tMTgpE3dlZWsgMjAgKDE1LUpBTi0xOCkTd2VlayAyMSAoMjItSkFOLTE4KRN3ZWVrIDIyICgyOS1KQU4tMTgpE3dlZWsgMjMgKDA1LUZFQi0xOCkTd2VlayAyNCAoMTItRkVCLTE4KRN3ZWVrIDI1ICgxOS1GRUItMTgpE3dlZWsgMjYgKDI2LUZFQi0xOCkTd2VlayAyNyAoMDUtTUFSLTE4KRN3ZWVrIDI4ICgxMi1NQVItMTgpE3dlZWsgMjkgKDE5LU1BUi0xOCkTd2VlayAzMCAoMjYtTUFSLTE4KRN3ZWVrIDMxICgwMi1BUFItMTgpE3dlZWsgMzIgKDA5LUFQUi0xOCkTd2VlayAzMyAoMTYtQVBSLTE4KRN3ZWVrIDM0ICgyMy1BUFItMTgpE3dlZWsgMzUgKDMwLUFQUi0xOCkTd2VlayAzNiAoMDctTUFZLTE4KRN3ZWVrIDM3ICgxNC1NQVktMTgpE3dlZWsgMzggKDIxLU1BWS0xOCkTd2VlayAzOSAoMjgtTUFZLTE4KRN3ZWVrIDQwICgwNC1KVU4tMTgpE3dlZWsgNDEgKDExLUpVTi0xOCkTd2VlayA0MiAoMTgtSlVOLTE4KRN3ZWVrIDQzICgyNS1KVU4tMTgpE3dlZWsgNDQgKDAyLUpVTC0xOCkTd2VlayA0NSAoMDktSlVMLTE4KRN3ZWVrIDQ2ICgxNi1KVUwtMTgpE3dlZWsgNDcgKDIzLUpVTC0xOCkTd2VlayA0OCAoMzAtSlVMLTE4KRN3ZWVrIDQ5ICgwNi1BVUctMTgpE3dlZWsgNTAgKDEzLUFVRy0xOCkTd2VlayA1MSAoMjAtQVVHLTE4KRUyATIBMwE0ATUBNgE3ATgBOQIxMAIxMQIxMgIxMwIxNAIxNQIxNgIxNwIxOAIxOQIyMAIyMQIyMgIyMwIyNAIyNQIyNgIyNwIyOAIyOQIzMAIzMQIzMgIzMwIzNAIzNQIzNgIzNwIzOAIzOQI0MAI0MQI0MgI0MwI0NAI0NQI0NgI0NwI0OAI0OQI1MAI1MRQrAzJnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2RkAgUPEA8WAh8CZ2QQFRIYLS0gUGxlYXNlIFNlbGVjdCBEZXB0IC0tHEJBIC0gQWNjb3VudGFuY3kgJiBFY29ub21pY3MWQkcgLSBHcmFkdWF0ZSBCdXNpbmVzcx5CTSAtIE1hbmFnZW1lbnQgJiBPcmdhbmlzYXRpb24MQ0wgLSBDZW50cmFsFERBIC0gQWR1bHQgRWR1Y2F0aW9uEUVBIC0gQXJjaGl0ZWN0dXJlFkVDIC0gQnVpbHQgRW52aXJvbm1lbnQbRUUgLSBFbmdpbmVlcmluZyBUZWNobm9sb2d5EkVUIC0gVHJhZGUgU3R1ZGllcxFIQSAtIEFwcGxpZWQgQXJ0cx9IQyAtIENyZWF0aXZlICYgUGVyZm9ybWluZyBBcnRzF0hMIC0gTGFuZ3VhZ2VzLCBUb3VyaXNtFkhNIC0gUFQgLSBNdXNpYyBTY2hvb2wMU0MgLSBTY2llbmNlGFNQIC0gQ29tcHV0aW5nIGFuZCBNYXRocy1YRSAtIERlcGFydG1lbnQgb2YgU3BvcnQgYW5kIEV4ZXJjaXNlIFNjaWVuY2UMWE4gLSBOdXJzaW5nFRIBJSA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCRCAyRUVDMkY0NjZEN0REMUU3RkY2NDQ3Q0JCQTQ4QzlFNyA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCRiA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRUQzQSA0MUEyQkNDRkY2OUFGOTNDMzI4NzcwODYwOTFCMDlGOCAwRjU1MjJCMDEwMENGOUZCRTNGMDg2NzQyNUVCMzVBQSA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCQyA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCQiA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCQSA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCNyA5QUYzRDExNUFBMkEzMDlGNkE2QzM1OTVFMEJEMjlENCA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCOCAyRUVDMkY0NjZEN0REMUU3RkY2NDQ3Q0JCQTQ4QzNDRSA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCNiA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVDMCA2QUJFMkVFNzAzQzA2MDMyMkVDODIxRjFDMkFBMTY3RSA1NDg3MTVGNzA4NzRCMkIxNTYxRERDOThGRTYxRTVCORQrAxJnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dkZAIHDxAPFgIfAmdkEBUTBTA5OjE1BTEwOjE1BTExOjE1BTEyOjE1BTEzOjE1BTE0OjE1BTE1OjE1BTE2OjE1BTE3OjE1BTE4OjAwBTE4OjE1BTE4OjMwBTE5OjAwBTE5OjE1BTE5OjMwBTIwOjAwBTIwOjE1BTIwOjMwBTIxOjAwFRMBMQEyATMBNAE1ATYBNwE4ATkCMTACMTECMTICMTMCMTQCMTUCMTYCMTcCMTgCMTkUKwMTZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2RkAgkPEA8WAh8CZ2QQFRQFMTc6MTUFMDk6MTUFMTA6MTUFMTE6MTUFMTI6MTUFMTM6MTUFMTQ6MTUFMTU6MTUFMTY6MTUFMTc6MTUFMTg6MDAFMTg6MTUFMTg6MzAFMTk6MDAFMTk6MTUFMTk6MzAFMjA6MDAFMjA6MTUFMjA6MzAFMjE6MDAVFAE4ATEBMgEzATQBNQE2ATcBOAE5AjEwAjExAjEyAjEzAjE0AjE1AjE2AjE3AjE4AjE5FCsDFGdnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZGQCCw8QDxYCHwJnZBAV%2FwIEICAgICAjU1BMVVMxNTc3RDkgLSBHIE9ubGluZSBEZWxpdmVyeSEtUm9vbV9GbGV4aUhyIC0gRmxleGkgSG91ciBPZmZpY2UuMC4xIFN0dWRpbyAtICBHcmFuYXJ5IEdyb3VuZCBmbG9vciAoQTUgU3R1ZGlvKRExMjEgLSBNZWRpY2FsIExhYhExMjIgLSBNZWRpY2FsIExhYhIxMjMgLSBTZW5zb3J5IFJvb20RMTI3IC0gTWVkaWNhbCBMYWIRMTI4IC0gTWVkaWNhbCBMYWIWMjIzIC0gTGVjdHVyZS9UdXRvcmlhbBYyMjQgLSBMZWN0dXJlL1R1dG9yaWFsFjIyNSAtIExlY3R1cmUvVHV0b3JpYWwWMjI2IC0gTGVjdHVyZS9UdXRvcmlhbBYyMjcgLSBMZWN0dXJlL1R1dG9yaWFsFjIyOCAtIExlY3R1cmUvVHV0b3JpYWwWMjI5IC0gTGVjdHVyZS9UdXRvcmlhbBIyMzAgLSBDb21wdXRlciBMYWIRM0QgLSBQcm9qZWN0IExhYnMdQSZCVDEgU3R1ZGlvIC0gRHJhd2luZyBTdHVkaW8dQSZCVDIgU1RVRElPIC0gRHJhd2luZyBTdHVkaW8dQSZCVDMgU1RVRElPIC0gRHJhd2luZyBTdHVkaW8dQSZCVDQgU3R1ZGlvIC0gRHJhd2luZyBTdHVkaW8XQTAwMiAtIExpYnJhcnkgQ29tcCBMYWIUQTAwNSAtIExpYnJhcnkgU3R1ZHkXQTAwNiAtIExlY3R1cmUvVHV0b3JpYWwaQTEgU3R1ZGlvIC0gRHJhd2luZyBTdHVkaW8XQTEwMSAtIENvbXB1dGVyIE1hYyBMYWIUQTEwMiAtIERlc2lnbiBTdHVkaW8MQTEwMyAtIE11c2ljEUExMDQgLSBNdXNpYyBSb29tDEExMDUgLSBNdXNpYwxBMTA2IC0gTXVzaWMMQTEwNyAtIE11c2ljDEExMDggLSBNdXNpYwxBMTA5IC0gTXVzaWMMQTExMCAtIE11c2ljDEExMTEgLSBNdXNpYwxBMTEyIC0gTXVzaWMMQTExMyAtIE11c2ljD0ExMTQgLSBDb21wdXRlchdBMTE1IC0gTGVjdHVyZS9UdXRvcmlhbBRBMTE2IC0gRGVzaWduIFN0dWRpbxRBMTE3IC0gRGVzaWduIFN0dWRpbxpBMiBTdHVkaW8gLSBEcmF3aW5nIFN0dWRpbxFBMjAxQSAtIEFuaW1hdGlvbhJBMjAxQiAtIEFydCBTdHVkaW8MQTIwMiAtIFZpZGVvDEEyMDMgLSBNdXNpYwxBMjA0IC0gTXVzaWMMQTIwNSAtIE11c2ljDEEyMDYgLSBNdXNpYwxBMjA3IC0gTXVzaWMMQTIwOCAtIE11c2ljDEEyMDkgLSBNdXNpYwxBMjEwIC0gTXVzaWMRQTIxMiAtIEFydCBTdHVkaW8RQTIxMyAtIEFydCBTdHVkaW8RQTIxNCAtIEFydCBTdHVkaW8aQTMgU3R1ZGlvIC0gRHJhd2luZyBTdHVkaW8aQTQgU3R1ZGlvIC0gRHJhd2luZyBTdHVkaW8ZQUFURU1QIC0gTGVjdHVyZS9UdXRvcmlhbBNBRzAzIC0gUmVzZWFyY2ggTGFiD0FHMDQgLSBSZXNlYXJjaA9BRzA3IC0gUmVzZWFyY2gTQUcwOCAtIFJlc2VhcmNoIExhYg9BRzA5IC0gUmVzZWFyY2gPQUcxMCAtIFJlc2VhcmNoFEFHMTQgLSBSZXNlYXJjaCBTZWFtFEFHMTUgLSBSZXNlYXJjaCBTZWFtF0FHMTYgLSBMZWN0dXJlL1R1dG9yaWFsFkFHMTcgLSBQaHN5Y2hvbG9neSBMYWIXQUcxOCAtIExlY3R1cmUvVHV0b3JpYWwXQUcyMCAtIExlY3R1cmUvVHV0b3JpYWwHQUcyMSAtICNBRzI1IC0gRWxlY3RyaWNhbCBTZWN1cnRpeSBXb3Jrc2hvcA9BRzI2IC0gQnVpbGRpbmcaQUcyNyAtIEVsZWN0cmljYWwgV29ya3Nob3AmQUczMSAtIE1lY2hhbmljYWwgRW5naW5lZXJpbmcgV29ya3Nob3ANQUczMiAtIEdhcmFnZRdBRzMzIC0gTGVjdHVyZS9UdXRvcmlhbBdBRzM0IC0gTGVjdHVyZS9UdXRvcmlhbBZBTDEgLSBMZWN0dXJlL1R1dG9yaWFsEUFMMTEgLSBNdXNpYyBSb29tFkFMMiAtIExlY3R1cmUvVHV0b3JpYWwWQUwzIC0gTGVjdHVyZS9UdXRvcmlhbA5BTFRBUiAtIFN0dWRpbxhBVDEwMyAtIExlY3R1cmUvVHV0b3JpYWwYQVQxMDQgLSBMZWN0dXJlL1R1dG9yaWFsGEFUMTA1IC0gTGVjdHVyZS9UdXRvcmlhbBZBVDEwNiAtIERyYXdpbmcgU3R1ZGlvFkFUMTA3IC0gRWxlY3RyaWNhbCBMYWIQQVQxMDggLSBDb21wdXRlchZBVDEwOSAtIEVsZWN0cmljYWwgTGFiGEFUMTEwIC0gTGVjdHVyZS9UdXRvcmlhbBhBVDExMSAtIExlY3R1cmUvVHV0b3JpYWwYQVQxMTIgLSBMZWN0dXJlL1R1dG9yaWFsEEFUMTIxIC0gQ29tcHV0ZXIYQVQxMjYgLSBMZWN0dXJlL1R1dG9yaWFsDkFUMTMwIC0gT2ZmaWNlMUFkdWx0IExpdGVyYWN5IE91dHJlYWNoIC0gQWR1bHQgTGl0ZXJhY3kgT3V0cmVhY2gOQjAxIC0gUmVzZWFyY2gOQjAyIC0gUmVzZWFyY2gOQjAzIC0gUmVzZWFyY2gWQjA3IC0gTGVjdHVyZS9UdXRvcmlhbA1CMDggLSBCaW9sb2d5DUIwOSAtIEJpb2xvZ3kOQjA5QSAtIEJpb2xvZ3kNQjEwIC0gQmlvbG9neQlCMTEgLSBOTVIPQjEyIC0gQ2hlbWlzdHJ5D0IxMyAtIENoZW1pc3RyeQ9CMTUgLSBDaGVtaXN0cnkPQjE2IC0gQ2hlbWlzdHJ5DUIxOCAtIFBoeXNpY3MNQjE5IC0gUGh5c2ljcwlCMjAgLSBTZW0OQjIxIC0gUmVzZWFyY2gQQjIzIC0gSW5zdHJ1bWVudBFCMjkgLSBQaWxvdCBQbGFudBFCQUtFUlkgLSBDb21wdXRlcglCQVIgLSBCYXIuQkVUTCAtIEJ1aWxkIEVudmlyb25tZW50IFRlY2hub2xvZ3kgTGFib3JhdG9yeRZCTDEgLSBMZWN0dXJlL1R1dG9yaWFsF0JMMTQgLSBMZWN0dXJlL1R1dG9yaWFsFkJMMiAtIExlY3R1cmUvVHV0b3JpYWwSQkwzIC0gQ29tcHV0ZXIgTGFiFkJMNCAtIExlY3R1cmUvVHV0b3JpYWwPQkw5IC0gQm9hcmRyb29tD0JXMSAtIEJ1aWxkaW5nIB9Cb3QgR2FyZGVucyAtIEJvdGFuaWNhbCBHYXJkZW5zF0MwMDEgLSBMZWN0dXJlL1R1dG9yaWFsF0MwMDIgLSBMZWN0dXJlL1R1dG9yaWFsF0MwMDMgLSBMZWN0dXJlL1R1dG9yaWFsF0MwMDQgLSBMZWN0dXJlL1R1dG9yaWFsF0MwMDUgLSBMZWN0dXJlL1R1dG9yaWFsF0MwMTQgLSBMZWN0dXJlL1R1dG9yaWFsDEMwNyAtIE9mZmljZQlDMTEgLSBDQUQXQzExMSAtIExlY3R1cmUvVHV0b3JpYWwXQzExNSAtIExlY3R1cmUvVHV0b3JpYWwSQzE1IC0gRGVtbyBLaXRjaGVuFUMxOSAtIFByb2R1Y3QgS2l0Y2hlbhZDMjAgLSBUcmFpbmluZyBLaXRjaGVuF0MyMDQgLSBMZWN0dXJlL1R1dG9yaWFsE0MyMDYgLSBDb21wdXRlciBMYWITQzIxMiAtIENvbXB1dGVyIExhYhJDMjMgLSBDb25zdHJ1Y3Rpb24PQzI0IC0gTWVjaGFuaWNzEEMyNSAtIFRlY2hub2xvZ3kWQzI2IC0gTGVjdHVyZS9UdXRvcmlhbBJDMjcgLSBSZXNlYXJjaCBMYWIGQzI4IC0gFkMyOSAtIExlY3R1cmUvVHV0b3JpYWwiQzMwIC0gQ29uc3RydWN0aW9uIE1hbmFnZW1lbnQgUm9vbRZDMzEgLSBMZWN0dXJlL1R1dG9yaWFsG0MzMiAtIFRlY2hub2xvZ3kgJiBDb21wdXRlchFDMzMgLSBFbGVjdHJvbmljcxFDMzQgLSBFbGVjdHJvbmljcxZDMzUgLSBMZWN0dXJlL1R1dG9yaWFsEkMzOCAtIFNjaWVuY2UgQ29tcBFDMzkgLSBFbGVjdHJvbmljcxJDMzlBIC0gRWxlY3Ryb25pY3MWQzQyIC0gTGVjdHVyZS9UdXRvcmlhbBJDNDcgLSBTdGFmZiBPZmZpY2URQzQ4IC0gRWxlY3Ryb25pY3MSQzQ4QSAtIEVsZWN0cm9uaWNzGEM1MSAtIFBNQlJDIFJlc2VhcmNoIExhYhNDRVJBTUlDUyAtIGNlcmFtaWNzEUNHTiBBVyAgLSBDR04gQVcgI0NHTiBEQU5DRSBTVFVESU8gLSBDR04gREFOQ0UgU1RVRElPEUNHTiBHWU0gLSBDR04gR1lNIUNHTiBTUE9SVFMgSEFMTCAtIENnbiBTcG9ydHMgSGFsbCpDR04gVFJBSU5JTkcgUk9PTSAxIC0gQ2duIFRyYWluaW5nIHJvb20gIDElQ0dOIFRSQUlOSU5HIFJPT00gMiAtIFRyYWluaW5nIHJvb20gMiVDR04gVFJBSU5JTkcgUk9PTSAzIC0gVHJhaW5pbmcgUm9vbSAzD0NIQVBFTCAtIENoYXBlbBZDTDEgLSBMZWN0dXJlL1R1dG9yaWFsFkNMMiAtIExlY3R1cmUvVHV0b3JpYWwWQ0wzIC0gTGVjdHVyZS9UdXRvcmlhbBJDTDQgLSBDb21wdXRlciBMYWIVQ291cnR5YXJkIC0gQ291cnR5YXJkEUQwMSAtIFBvc3QgLSBHcmFkEkQwMiAtIENvbXB1dGVyIExhYhBEMDQgLSBNdWx0aW1lZGlhEEQwNSAtIE11bHRpbWVkaWEORDA4IC0gUmVzZWFyY2gWRDExIC0gTGVjdHVyZS9UdXRvcmlhbA9EMTIgLSBFeGFtIFJvb20MRDI1IC0gb2ZmaWNlEUQyOEEgLSBSZXRhaWwgTGFiDURBTkNFIC0gRGFuY2URREFSSyBST09NIC0gUGhvdG8WRTAzIC0gTGVjdHVyZS9UdXRvcmlhbBZFMDQgLSBMZWN0dXJlL1R1dG9yaWFsFkUwNyAtIExlY3R1cmUvVHV0b3JpYWwWRTEzIC0gTGVjdHVyZS9UdXRvcmlhbBZFMTUgLSBMZWN0dXJlL1R1dG9yaWFsF0UxOUEgLSBMZWN0dXJlL1R1dG9yaWFsF0UxOUIgLSBMZWN0dXJlL1R1dG9yaWFsHUVUUkMxIC0gUmVzZWFyY2ggLyBQbmV1bWF0aWNzHUVUUkMyIC0gUmVzZWFyY2ggLyBQbmV1bWF0aWNzHUVUUkMzIC0gUmVzZWFyY2ggLyBNZWNoYW5pY2FsK0VubmlzY29ydGh5IE91dHJlYWNoIC0gRW5uaXNjb3J0aHkgT3V0cmVhY2gWRjAxIC0gTGVjdHVyZS9UdXRvcmlhbBZGMDIgLSBMZWN0dXJlL1R1dG9yaWFsFkYwMyAtIExlY3R1cmUvVHV0b3JpYWwWRjA0IC0gTGVjdHVyZS9UdXRvcmlhbBZGMDYgLSBMZWN0dXJlL1R1dG9yaWFsFkYwNyAtIExlY3R1cmUvVHV0b3JpYWwWRjA5IC0gTGVjdHVyZS9UdXRvcmlhbBZGMjAgLSBMZWN0dXJlL1R1dG9yaWFsFkYyMyAtIExlY3R1cmUvVHV0b3JpYWwWRjI2IC0gTGVjdHVyZS9UdXRvcmlhbBZGMjcgLSBMZWN0dXJlL1R1dG9yaWFsFkYyOCAtIExlY3R1cmUvVHV0b3JpYWwXRjI4QSAtIExlY3R1cmUvVHV0b3JpYWwWRjI5IC0gTGVjdHVyZS9UdXRvcmlhbBZGMzAgLSBMZWN0dXJlL1R1dG9yaWFsIkZURyBIQSBESVNTIFRCQSAtIExlY3R1cmUvVHV0b3JpYWwYRlRHMTAgLSBMZWN0dXJlL1R1dG9yaWFsGEZURzExIC0gTGVjdHVyZS9UdXRvcmlhbBhGVEcxMiAtIExlY3R1cmUvVHV0b3JpYWwYRlRHMTMgLSBMZWN0dXJlL1R1dG9yaWFsGEZURzE0IC0gTGVjdHVyZS9UdXRvcmlhbBRGVEcxNSAtIENvbnN0cnVjdGlvbhhGVEcxOCAtIExlY3R1cmUvVHV0b3JpYWwYRlRHMTkgLSBMZWN0dXJlL1R1dG9yaWFsGEZURzIwIC0gTGVjdHVyZS9UdXRvcmlhbBZGVEcyMSAtIFBzeWNob2xvZ3kgTGFiGEZURzIyIC0gTGVjdHVyZS9UdXRvcmlhbBhGVEcyMyAtIExlY3R1cmUvVHV0b3JpYWwYRlRHMjQgLSBMZWN0dXJlL1R1dG9yaWFsGEZURzI1IC0gTGVjdHVyZS9UdXRvcmlhbBhGVEcyOSAtIExlY3R1cmUvVHV0b3JpYWwlRyBPbmxpbmUgRGVsaXZlcnkgLSBHIE9ubGluZSBEZWxpdmVyeRZHMTIgLSBMZWN0dXJlL1R1dG9yaWFsFkcxNyAtIExlY3R1cmUvVHV0b3JpYWwWRzE4IC0gTGVjdHVyZS9UdXRvcmlhbBZHMTkgLSBMZWN0dXJlL1R1dG9yaWFsFkcyMCAtIExlY3R1cmUvVHV0b3JpYWweR1IgQVQxIFN0dWRpbyAtIERyYXdpbmcgU3R1ZGlvHkdSIEFUMiBTdHVkaW8gLSBEcmF3aW5nIFN0dWRpbx9HUiBBVDMgU3R1ZGlvIC0gQ29tcHV0ZXIgU3R1ZGlvH0dSIEFUNCBTdHVkaW8gLSBDb21wdXRlciBTdHVkaW8iR1IgTGVjdHVyZSBIYWxsIC0gTGVjdHVyZS9UdXRvcmlhbBZHU0sgLSBUcmFpbmluZyBLaXRjaGVuCUdZTSAtIEdZTRhIQSAwNiAtIExlY3R1cmUvVHV0b3JpYWwYSEEgMDcgLSBMZWN0dXJlL1R1dG9yaWFsGEhBIDA4IC0gTGVjdHVyZS9UdXRvcmlhbBhIQSAxNyAtIExlY3R1cmUvVHV0b3JpYWwYSEEgMTggLSBMZWN0dXJlL1R1dG9yaWFsFUhBIDIwIC0gRGVzaWduIFN0dWRpbxhIQSAyMSAtIExlY3R1cmUvVHV0b3JpYWwQSEEgMjIgLSBDb21wdXRlch5IQSBPRkZJQ0UgLSBIQSBMZWN0dXJlciBPZmZpY2UTSEEyMyAtIG1lZXRpbmcgcm9vbR5IQyBPRkZJQ0UgLSBIQyBMZWN0dXJlciBPZmZpY2UVSEwgT0ZGSUNFIC0gSEwgT2ZmaWNlG0lUMTAxIC0gQ29tcHV0ZXIgTXVsdGltZWRpYRhJVDEwMiAtIENvbXB1dGVyIE1hYyBMYWIgSVQxMDMgLSBDb21wdXRlciBHYW1lcyBGb3JlbnNpY3MUSVQxMTggLSBDb21wdXRlciBMYWIUSVQxMTkgLSBDb21wdXRlciBMYWIUSVQxMjAgLSBDb21wdXRlciBMYWIUSVQyMDEgLSBDb21wdXRlciBMYWIQSVQyMDIgLSBDb21wdXRlchBJVDIwMyAtIENvbXB1dGVyFElUMjIwIC0gQ29tcHV0ZXIgTGFiFElUMjIxIC0gQ29tcHV0ZXIgTGFiFElUMjIyIC0gQ29tcHV0ZXIgTGFiFElURzAxIC0gQ29tcHV0ZXIgTGFiFElURzAyIC0gQ29tcHV0ZXIgTGFiFElURzAzIC0gQ29tcHV0ZXIgTGFiFElURzE3IC0gQ29tcHV0ZXIgTGFiFElURzE4IC0gQ29tcHV0ZXIgTGFiFElURzE5IC0gQ29tcHV0ZXIgTGFiHEtJTERBTFRPTiAtIExlY3R1cmUvVHV0b3JpYWwVTGlmZSBEcmF3IC0gTGlmZSBEcmF3FE1PT1JFUEFSSyAtIEZPT0QgTEFCDU5QTCAtIFBoeXNpY3MNUEhPVE8gLSBQaG90bxJQTDEgLSBQbHVtYmluZyBMYWIUUE9PTCAtIFN3aW1taW5nIFBvb2wcUE9TVC1HUkFEIC0gTGVjdHVyZS9UdXRvcmlhbBdQVzEgLSBQbHVtYmluZyBXb3Jrc2hvcBdQVzIgLSBQbHVtYmluZyBXb3Jrc2hvcBdQVzMgLSBQbHVtYmluZyBXb3Jrc2hvcBBSQjEwNCAtIFJlc2VhcmNoEFJCMTA1IC0gUmVzZWFyY2gHUkVTVCAtIB1SU0NUUkFDSyAtIFJTQyBBdGxldGljcyBUcmFjax5TQyBPRkZJQ0UgLSBTQyBMZWN0dXJlciBPZmZpY2UeU0ggT0ZGSUNFIC0gU0ggTGVjdHVyZXIgT2ZmaWNlFlNJVEUgVklTSVQgLSBQbGFjZW1lbnQeU04gT0ZGSUNFIC0gU04gTGVjdHVyZXIgT2ZmaWNlHlNQIE9GRklDRSAtIFNQIExlY3R1cmVyIE9mZmljZRJTUEEgLSBQcm9qZWN0IExhYnMiU1BBLU1ldGFsIC0gU3BlY2lhbCBQdXJwb3NlLSBNZXRhbCZTUEEtUGxhc3RlciAtIFNwZWNpYWwgUHVycG9zZS0gUGxhc3RlciJTUEEtUHJpbnQgLSBTcGVjaWFsIFB1cnBvc2UtIFByaW50FlNUVURJTzEgLSBQcm9qZWN0IExhYnMWU3R1ZGlvIC0gRGVzaWduIFN0dWRpbx1UQkEgMV8gRUMgIC0gTGVjdHVyZS9UdXRvcmlhbAhUTDExNCAtIBRUTDExNiAtIENvbXB1dGVyIExhYhBUTDEyMCAtIENvbXB1dGVyEFRMMTIxIC0gQ29tcHV0ZXIQVEwxMjggLSBDb21wdXRlchBUTDEyOSAtIENvbXB1dGVyFVRMMTMzIEdZTSAtIFRMMTMzIEdZTSFUTDE0OCBDbGluaWNhbCBsYWIgLSBDbGluaWNhbCBMYWIoVEwxNDkgSFBMIC0gSHVtYW4gUGVyZm9ybWFuY2UgTGFiICBMYWIgMShUTDE1NSBIUEwgLSBIdW1hbiBQZXJmb3JtYW5jZSBMYWIgIExhYiAyGFRMMTU3IC0gTGVjdHVyZS9UdXRvcmlhbBhUTDE1OCAtIExlY3R1cmUvVHV0b3JpYWwYVEwxNTkgLSBMZWN0dXJlL1R1dG9yaWFsGFRMMjIxIC0gTGVjdHVyZS9UdXRvcmlhbBhUTDIyNSAtIExlY3R1cmUvVHV0b3JpYWwYVEwyMjggLSBMZWN0dXJlL1R1dG9yaWFsGFRMMjM1IC0gTGVjdHVyZS9UdXRvcmlhbBRUTDIzNiAtIENvbXB1dGVyIExhYhRUTDIzOCAtIExlY3R1cmUgUm9vbRtUTDI0NChBKSAtIExlY3R1cmUvVHV0b3JpYWwbVEwyNDQoQikgLSBMZWN0dXJlL1R1dG9yaWFsGFRMMjQ1IC0gTGVjdHVyZS9UdXRvcmlhbBhUTDI0OSAtIExlY3R1cmUvVHV0b3JpYWwYVEwyNTAgLSBMZWN0dXJlL1R1dG9yaWFsGFRMMjUxIC0gTGVjdHVyZS9UdXRvcmlhbBhUTDI1MiAtIExlY3R1cmUvVHV0b3JpYWwUVExHMDMgLSBEZW1vIEtpdGNoZW4LVExHMDkgLSBCQVILVExHMTAgLSBCQVISVExHMjEgLSBBdWRpdG9yaXVtHlRMRzIyIC0gRmluZSBEaW5pbmcgUmVzdGF1cmFudBxUTEcyNyAtIFByb2R1Y3Rpb24gS2l0Y2hlbiAxHFRMRzI4IC0gUHJvZHVjdGlvbiBLaXRjaGVuIDIOVExHMjkgLSBCSVNUUk8aVExHMzQgLSBUcmFpbmluZyBLaXRjaGVuIDIaVExHMzUgLSBUcmFpbmluZyBLaXRjaGVuIDEWVExHNDggLSBMYXJkZXIgS2l0Y2hlbhZUTEc1MSAtIFBhc3RyeSBLaXRjaGVuGlRMRzYwIC0gVHJhaW5pbmcgS2l0Y2hlbiAzGVRMRzYyIC0gSW5kdWN0aW9uIEtpdGNoZW4UVzAyIC0gRm9ydWxhdGlvbiBMYWIRVzAzIC0gUGh5c2ljcyBMYWIQVzA0IC0gT3B0aWNzIGxhYhFXMDUgLSBQaHlzaWNzIExhYhFXMDYgLSBQaHlzaWNzIExhYglXMDcgLSBMYWINVzA4IC0gTmV3IExhYhZXMDkgLSBMZWN0dXJlL1R1dG9yaWFsFlcxMCAtIExlY3R1cmUvVHV0b3JpYWwWVzExIC0gTGVjdHVyZS9UdXRpb3JhbA9XMTIgLSBTdXJ2ZXlpbmcWVzEzIC0gTGVjdHVyZS9UdXRvcmlhbBZXMTQgLSBMZWN0dXJlL1R1dG9yaWFsDlcxOCAtIEZvcmVzdHJ5DlcxOSAtIENvbXB1dGVyC1cyMCAtIENpdmlsG1cyMSAtIEJ1aWx0IEVudmlyb25tZW50IExhYhtXRUlHSFRTIFJPT00gLSBXRUlHSFRTIFJPT00ZWEVfVEJBIC0gTGVjdHVyZS9UdXRvcmlhbCVfQXRobG9uZSBPdXRyZWFjaCAtIF9BdGhsb25lIE91dHJlYWNoGl9CUiBUQkEgLSBMZWN0dXJlL1R1dG9yaWFsHV9DUyBPZmZpY2UgLSBMZWN0dXJlL1R1dG9yaWFsGl9HUyBUQkEgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtQkEgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtQkcgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtQk0gLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtRUEgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtRUMgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtRUUgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtU0MgLSBMZWN0dXJlL1R1dG9yaWFsI19NQyBBQSBPZmZpY2UtU1AgLSBMZWN0dXJlL1R1dG9yaWFsHV9NQyBPRkZJQ0UgLSBMZWN0dXJlL1R1dG9yaWFsHF9NQyBPZmZpY2UgWE4gLSBEaXNzZXJ0YXRpb24aX01DIFRCQSAtIExlY3R1cmUvVHV0b3JpYWwnX1N0IFBhdHMgT3V0cmVhY2ggIC0gX1N0IFBhdHMgT3V0cmVhY2ggGl9UQkEvQkUgLSBMZWN0dXJlL1R1dG9yaWFsJV9UQkEvQkUgQ29sbGVnZSBTdCAtIExlY3R1cmUvVHV0b3JpYWwaX1RCQS9EQSAtIExlY3R1cmUvVHV0b3JpYWwlX1RodXJsZXMgT3V0cmVhY2ggLSBfVGh1cmxlcyBPdXRyZWFjaBX%2FAgIgIAwjU1BMVVMxNTc3RDkNLVJvb21fRmxleGlIcgowLjEgU3R1ZGlvAzEyMQMxMjIDMTIzAzEyNwMxMjgDMjIzAzIyNAMyMjUDMjI2AzIyNwMyMjgDMjI5AzIzMAIzRAxBJkJUMSBTdHVkaW8MQSZCVDIgU1RVRElPDEEmQlQzIFNUVURJTwxBJkJUNCBTdHVkaW8EQTAwMgRBMDA1BEEwMDYJQTEgU3R1ZGlvBEExMDEEQTEwMgRBMTAzBEExMDQEQTEwNQRBMTA2BEExMDcEQTEwOARBMTA5BEExMTAEQTExMQRBMTEyBEExMTMEQTExNARBMTE1BEExMTYEQTExNwlBMiBTdHVkaW8FQTIwMUEFQTIwMUIEQTIwMgRBMjAzBEEyMDQEQTIwNQRBMjA2BEEyMDcEQTIwOARBMjA5BEEyMTAEQTIxMgRBMjEzBEEyMTQJQTMgU3R1ZGlvCUE0IFN0dWRpbwZBQVRFTVAEQUcwMwRBRzA0BEFHMDcEQUcwOARBRzA5BEFHMTAEQUcxNARBRzE1BEFHMTYEQUcxNwRBRzE4BEFHMjAEQUcyMQRBRzI1BEFHMjYEQUcyNwRBRzMxBEFHMzIEQUczMwRBRzM0A0FMMQRBTDExA0FMMgNBTDMFQUxUQVIFQVQxMDMFQVQxMDQFQVQxMDUFQVQxMDYFQVQxMDcFQVQxMDgFQVQxMDkFQVQxMTAFQVQxMTEFQVQxMTIFQVQxMjEFQVQxMjYFQVQxMzAXQWR1bHQgTGl0ZXJhY3kgT3V0cmVhY2gDQjAxA0IwMgNCMDMDQjA3A0IwOANCMDkEQjA5QQNCMTADQjExA0IxMgNCMTMDQjE1A0IxNgNCMTgDQjE5A0IyMANCMjEDQjIzA0IyOQZCQUtFUlkDQkFSBEJFVEwDQkwxBEJMMTQDQkwyA0JMMwNCTDQDQkw5A0JXMQtCb3QgR2FyZGVucwRDMDAxBEMwMDIEQzAwMwRDMDA0BEMwMDUEQzAxNANDMDcDQzExBEMxMTEEQzExNQNDMTUDQzE5A0MyMARDMjA0BEMyMDYEQzIxMgNDMjMDQzI0A0MyNQNDMjYDQzI3A0MyOANDMjkDQzMwA0MzMQNDMzIDQzMzA0MzNANDMzUDQzM4A0MzOQRDMzlBA0M0MgNDNDcDQzQ4BEM0OEEDQzUxCENFUkFNSUNTB0NHTiBBVyAQQ0dOIERBTkNFIFNUVURJTwdDR04gR1lND0NHTiBTUE9SVFMgSEFMTBNDR04gVFJBSU5JTkcgUk9PTSAxE0NHTiBUUkFJTklORyBST09NIDITQ0dOIFRSQUlOSU5HIFJPT00gMwZDSEFQRUwDQ0wxA0NMMgNDTDMDQ0w0CUNvdXJ0eWFyZANEMDEDRDAyA0QwNANEMDUDRDA4A0QxMQNEMTIDRDI1BEQyOEEFREFOQ0UJREFSSyBST09NA0UwMwNFMDQDRTA3A0UxMwNFMTUERTE5QQRFMTlCBUVUUkMxBUVUUkMyBUVUUkMzFEVubmlzY29ydGh5IE91dHJlYWNoA0YwMQNGMDIDRjAzA0YwNANGMDYDRjA3A0YwOQNGMjADRjIzA0YyNgNGMjcDRjI4BEYyOEEDRjI5A0YzMA9GVEcgSEEgRElTUyBUQkEFRlRHMTAFRlRHMTEFRlRHMTIFRlRHMTMFRlRHMTQFRlRHMTUFRlRHMTgFRlRHMTkFRlRHMjAFRlRHMjEFRlRHMjIFRlRHMjMFRlRHMjQFRlRHMjUFRlRHMjkRRyBPbmxpbmUgRGVsaXZlcnkDRzEyA0cxNwNHMTgDRzE5A0cyMA1HUiBBVDEgU3R1ZGlvDUdSIEFUMiBTdHVkaW8NR1IgQVQzIFN0dWRpbw1HUiBBVDQgU3R1ZGlvD0dSIExlY3R1cmUgSGFsbANHU0sDR1lNBUhBIDA2BUhBIDA3BUhBIDA4BUhBIDE3BUhBIDE4BUhBIDIwBUhBIDIxBUhBIDIyCUhBIE9GRklDRQRIQTIzCUhDIE9GRklDRQlITCBPRkZJQ0UFSVQxMDEFSVQxMDIFSVQxMDMFSVQxMTgFSVQxMTkFSVQxMjAFSVQyMDEFSVQyMDIFSVQyMDMFSVQyMjAFSVQyMjEFSVQyMjIFSVRHMDEFSVRHMDIFSVRHMDMFSVRHMTcFSVRHMTgFSVRHMTkJS0lMREFMVE9OCUxpZmUgRHJhdwlNT09SRVBBUksDTlBMBVBIT1RPA1BMMQRQT09MCVBPU1QtR1JBRANQVzEDUFcyA1BXMwVSQjEwNAVSQjEwNQRSRVNUCFJTQ1RSQUNLCVNDIE9GRklDRQlTSCBPRkZJQ0UKU0lURSBWSVNJVAlTTiBPRkZJQ0UJU1AgT0ZGSUNFA1NQQQlTUEEtTWV0YWwLU1BBLVBsYXN0ZXIJU1BBLVByaW50B1NUVURJTzEGU3R1ZGlvClRCQSAxXyBFQyAFVEwxMTQFVEwxMTYFVEwxMjAFVEwxMjEFVEwxMjgFVEwxMjkJVEwxMzMgR1lNElRMMTQ4IENsaW5pY2FsIGxhYglUTDE0OSBIUEwJVEwxNTUgSFBMBVRMMTU3BVRMMTU4BVRMMTU5BVRMMjIxBVRMMjI1BVRMMjI4BVRMMjM1BVRMMjM2BVRMMjM4CFRMMjQ0KEEpCFRMMjQ0KEIpBVRMMjQ1BVRMMjQ5BVRMMjUwBVRMMjUxBVRMMjUyBVRMRzAzBVRMRzA5BVRMRzEwBVRMRzIxBVRMRzIyBVRMRzI3BVRMRzI4BVRMRzI5BVRMRzM0BVRMRzM1BVRMRzQ4BVRMRzUxBVRMRzYwBVRMRzYyA1cwMgNXMDMDVzA0A1cwNQNXMDYDVzA3A1cwOANXMDkDVzEwA1cxMQNXMTIDVzEzA1cxNANXMTgDVzE5A1cyMANXMjEMV0VJR0hUUyBST09NBlhFX1RCQRFfQXRobG9uZSBPdXRyZWFjaAdfQlIgVEJBCl9DUyBPZmZpY2UHX0dTIFRCQRBfTUMgQUEgT2ZmaWNlLUJBEF9NQyBBQSBPZmZpY2UtQkcQX01DIEFBIE9mZmljZS1CTRBfTUMgQUEgT2ZmaWNlLUVBEF9NQyBBQSBPZmZpY2UtRUMQX01DIEFBIE9mZmljZS1FRRBfTUMgQUEgT2ZmaWNlLVNDEF9NQyBBQSBPZmZpY2UtU1AKX01DIE9GRklDRQ1fTUMgT2ZmaWNlIFhOB19NQyBUQkESX1N0IFBhdHMgT3V0cmVhY2ggB19UQkEvQkUSX1RCQS9CRSBDb2xsZWdlIFN0B19UQkEvREERX1RodXJsZXMgT3V0cmVhY2gUKwP%2FAmdnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZ2dnZGQCDQ8PFgIfAQUJMzgyIFJvb21zZGRkVxCgDm3c35hDwZ5EEzNCmf6n0t0%3D&__VIEWSTATEGENERATOR=B2F77E7E&__EVENTVALIDATION=%2FwEW%2FQMCibm6BAK0o6DKAgK63%2FvACALf2pXeBQKktL%2BwCQKEtf%2BuCQKytbeuCQKytYOuCQK3tcuuCQKytd%2BuCQKytcuuCQLf2pXeBQLIpruTAQLGyZH9DQLFyZH9DQLEyZH9DQLDyZH9DQLCyZH9DQLByZH9DQLQyZH9DQLfyZH9DQLHydH%2BDQLHyd3%2BDQLHydn%2BDQLHyeX%2BDQLHyeH%2BDQLHye3%2BDQLHyen%2BDQLHyfX%2BDQLHybH9DQLHyb39DQLGydH%2BDQLGyd3%2BDQLGydn%2BDQLGyeX%2BDQLGyeH%2BDQLGye3%2BDQLGyen%2BDQLGyfX%2BDQLGybH9DQLGyb39DQLFydH%2BDQLFyd3%2BDQLFydn%2BDQLFyeX%2BDQLFyeH%2BDQLFye3%2BDQLFyen%2BDQLFyfX%2BDQLFybH9DQLFyb39DQLEydH%2BDQLEyd3%2BDQLEydn%2BDQLEyeX%2BDQLEyeH%2BDQLEye3%2BDQLEyen%2BDQLEyfX%2BDQLEybH9DQLEyb39DQLDydH%2BDQLDyd3%2BDQLxq8fqAQKKxe2EDQLzp%2F1iAp2BmrcBAvOnpdQDAsaB57sCAruQnccCAqLHkLAFAvOn2boNAvOn9cEEAvOnsfMKAvOnyegLAu2snooGAvOnrYYIAruY7awDAvOn5bcDApaJ65QIAtG775QHAvOnkXsC4qSUxAEC7cu%2Bqg0C7Mu%2Bqg0C78u%2Bqg0C7su%2Bqg0C6cu%2Bqg0C6Mu%2Bqg0C68u%2Bqg0C%2Bsu%2Bqg0C9cu%2Bqg0C7cv%2BqQ0C7cvyqQ0C7cv2qQ0C7cvKqQ0C7cvOqQ0C7cvCqQ0C7cvGqQ0C7cvaqQ0C7cueqg0C7cuSqg0C%2FpGizQEC5v6Iow0C8f6Iow0C8P6Iow0C8%2F6Iow0C8v6Iow0C9f6Iow0C9P6Iow0C9%2F6Iow0C5v6Iow0C6f6Iow0C8f7IoA0C8f7EoA0C8f7AoA0C8f78oA0C8f74oA0C8f70oA0C8f7woA0C8f7soA0C8f6oow0C8f6kow0C2teKyAYC2rigpQoCqoLO4AsC1OWL8wUCutSobgKewKmHAwKF18usCQKgvtSxDwK82dyGBAKLs5zYDAKjvtSxDwLOhPbGAgL165DsCAKQ8rLxDgK%2F2dyGBAKKs5zYDAKxmr7tAgLy%2BYvyDQLXuJC4CgL9vMC3CgKQk5qfDwKzkpqfDwLmvcC3CgKj%2Ba%2BZCAKj%2BeP3AgKj%2Bd%2BsDQLN%2BcK8AgKj%2Bb%2FkAQKj%2BauZCAKj%2BYe%2BAwKj%2BfPSCwKj%2Be%2F3AgKj%2BdusDQKj%2BbfBBQKj%2BeOoAwKj%2Bd%2FNCwLOz%2B3UDALOz9mJBwLOz7WuDgLOz6HDBgLOz534AQLOz4mdCALOz%2BWxAwLOz9HWCwLN%2BcbeAwKq%2F4aKBAKr%2F4aKBAKj%2BZeZCAKj%2BYO%2BAwKj%2Bf%2FSCwKj%2Bev3AgKj%2BcesDQKj%2BbPBBQKj%2Be%2BoAwKj%2BdvNCwLOz%2BnUDALOz7GuDgLOz63DBgLOz5n4AQLN%2Ber0AwLN%2Be6WAwLqjsLNBAKj%2Bb%2BwAwKj%2BavVCwKj%2Be%2FDBQKj%2BZurAwKj%2BffPCwLOz4XXDALOz7X6AQLOz6GfCALOz520AwLOz4npCwLOz6WwCQL11qf8AgL11pORDQL11sOkDgL11r%2FZBgL11qv%2BAQKQvbymAwKQvajbCwKQvYTwAgKQvfCUDQLOz%2FGZAwLOz62MBwL11pOvCQKQvby0DwL3jua9AgKHxrxbAozGvFsCjca8WwKCxrxbAoPGvFsCiMa8WwKJxrxbAoDGqPALAoHGqPALAobGqPALAoHGhJUCAoLGhJUCAoDG8MkKAqupw%2B4LAsnPoYcDAvTWw6wJApO97LEPAu%2FY1IYEAvqylNgMAuGZtu0CAuGZotwPAqL5g%2FINAsnPrYcDAvTWz6wJApO96LEPAqXrlOwIAsDxtvEOAvqykNgMAuGZsu0CAqL5j%2FINAsnPqYcDApO91LEPAuGZvu0CAp%2Fv%2F9EPAtTa3LgJAp6I25kCAsnP8ZkDAsnP4foBAvTWk68JApO9vLQPAr6E3tkCAuGZhuACAsnPhZoDAurQk9kCAq35s%2BQBAq35r5kIAq35m74DAq3599ILAq354%2FcCAsjPkfgBAu7Y1IYEAsjPrYcDAsjP2YkHAsjPiZ0IAqTrlOwIAuCZsu0CAq35j%2FINAq35%2F9ILAq35x6wNAsjPsa4OApK91LEPArmE9sYCAqTrkOwIAsPxsvEOAu7Y3IYEAsWynNgMAuCZvu0CAq35i%2FINAsjPlYcDAvfWt6wJApK90LEPArmE8sYCAqTrnOwIAsWymNgMAuCZuu0CAuCZltwPAvfWs6wJAu7YxIYEAsWyhNgMAsWy8LYIAsjPnYcDAqXuvs0HAszloYQGAvf9mP0BAo3nq8AMAq3ck78FAvTWw%2FIFAoeS5bICAqL5h9gJAof47tELAsjP8ZkDAvfWk68JApK9vLQPArmE3tkCAtb%2F6vcKAsvPoYcDAvbWw6wJAriEjscCAqfrqOwIAsSylNgMAsvPrYcDAvbWz6wJAqfrkOwIAsSyiLcIArOj0MYPAoSzspILApy97LEPAruEjscCAujY1IYEApy96LEPAqbrlOwIAuKZrtwPAuKZmvEGAojSo9UJAonSo9UJAo7So9UJAveCitwCAtXPoYcDAvDWw6wJAp%2B97LEPArqEjscCAszxyvEOAuvY1IYEAu2Ztu0CAq75j%2FINAp%2B91LEPAszxsvEOAuvY3IYEAsaynNgMAsayiLcIAu2Zvu0CAq75i%2FINAvCKmasJAv3xid0LAvzxid0LAv%2Fxid0LAv7xid0LAvnxid0LAvjxid0LAvXxid0LAvTxid0LAv3x5fECAvzx5fECAv%2Fx5fECAv7x5fECAvnx5fECAvjx5fECAvTx5fECAsnQirADAvPWz6wJAurY0IYEAsGykNgMAuyZsu0CAqn5j%2FINAq%2BHlu0JAq%2BHgrcIAq%2BHjq0GAqyH%2Bv4IAryAoNcJAqbokPgNAujF9JIJAuzpy4wIAu%2Fpy4wIAprpy4wIAu%2Fpp6EDAprpp6EDAuLpk8YLAu3pk8YLAuDpk8YLAsLcpqANAuLWg%2BsMAsLcvuwMAsLc2toKApnEvFsCnsS8WwKfxLxbApDEqPALApHEqPALApjEhJUCAoSr2eAGAoWr2eAGArqr2eAGAoeroboIAoSroboIAoWroboIAq%2F%2BnbgDAqz%2BnbgDAq3%2BnbgDAqn%2Bid0LAqb%2Bid0LAqf%2Bid0LAvKbgK8NAsilxP4GAsmLn98CAvr%2Bzo0DAtC1wkwC%2F8%2FxmQMCpbO78wICmPKT2g4C%2F8%2BFmgMCmtanrwkCgb3AtA8Ct8L0TAK2wvRMAuOh2d0GAuzAqqwNArngvuwMArngytoLAovUzfINArng0p4KArng6uoJAujg0%2BcOAtvZp64JArn9stkPAv%2Fsk%2FMIAtr90asMAoe8nuoFAqvgw%2BsEAvHCiPgLAvfCiPgLAv3C5JwCAvzC5JwCAsXC5JwCAsTC5JwCAobG4scJApPIgpgKApy40tADAoCkzowMAvbCuIsMAsXCuIsMAsTCuIsMApupgaIIAp%2BpgaIIAuCpgaIIAp%2Bp%2FUYCkqn9RgLgqf1GApW%2FmoMEArCGpKgKAp%2Bp6fsLAuOp6fsLApipxZACApupxZACAp6pxZACAoD8%2Fa8DAor8%2Fa8DAoP86cQLAoL8xfkCAoH8xfkCAoT8xfkCAov8xfkCAor8xfkCAof8sZ4NAob8sZ4NAov8rbMEAoL8megMAoP89YwHAoH89YwHAuPWw6wJAo697LEPArWEjscCAtDqqOwIAv%2FxyvEOAprY1IYEAvGylNgMApyZtu0CAtn4g%2FINAsTPrYcDAuPWz6wJAo696LEPArWEiscCAvGykNgMApyZsu0CAtn4j%2FINAsTPqYcDApq%2FsZwDArWHue0FAsTfmuQLAra7%2BtQHArL9u4AJAuuS2IsHAsSQx%2FcBAsSQr0ECxJCX5AwC%2F4Lo4QwC%2F4KAqA0C%2F4KY9QEC6aa%2B%2FAcC6aayuA0CwtSriQwCq4r3qwICu5OG0Q8Cn6KrywgC7bXyQQKI1fTcBAL50IKXCALD1PiADwL%2Fl8nCBQL%2B6YyoClGWqN1LA0l67JjXgpMmeyWNqELG&hProgram=&hStudentcount=&cboSchool=%25&CboWeeks='"${3:-10}"'&CboDept=%25&CboStartTime=1&CboEndTime=8&CboLocation='"$1"'&BtnRetrieve=Generate+Timetable' --compressed > "$2/$1-w${3:-10}".html
@@ -0,0 +1,140 @@
+package main
+
+import (
+	e "errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	auth "github.com/auth0-community/go-auth0"
+	"github.com/go-chi/render"
+
+	fft "github.com/thailekha/rooms-checker-go/api"
+)
+
+// ErrorKind is an entry of the error catalog. Clients match on Code or
+// Type, so neither may change or be reused once released.
+type ErrorKind struct {
+	Code        int64  `json:"code"`
+	Type        string `json:"type"`
+	Status      int    `json:"status"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// 4002 outside_teaching_hours is retired, free now outside teaching hours
+// answers that no room is free instead.
+var (
+	kindInvalidRequest      = ErrorKind{1000, "invalid_request", 400, "Invalid request.", "The request body or a query parameter could not be read"}
+	kindValidationFailed    = ErrorKind{1001, "validation_failed", 400, "Invalid request.", "Fields of the request failed validation, fields tells which and why"}
+	kindInvalidWeek         = ErrorKind{1002, "invalid_week", 400, "Invalid request.", "The week is not one the timetable form offers"}
+	kindUnauthorized        = ErrorKind{2000, "unauthorized", 401, "Unauthorized request.", "The bearer token is missing, expired or invalid"}
+	kindNoSubject           = ErrorKind{2001, "token_without_subject", 401, "Unauthorized request.", "The bearer token does not say who the user is"}
+	kindInsufficientScope   = ErrorKind{2002, "insufficient_scope", 403, "Insufficient scope", "The bearer token lacks the scope the endpoint needs"}
+	kindNotFound            = ErrorKind{3000, "not_found", 404, "Not found", "There is nothing at this address"}
+	kindUnknownRoom         = ErrorKind{3001, "unknown_room", 404, "Not found", "The room is not one of the rooms that can be searched"}
+	kindNoSuchSearch        = ErrorKind{3002, "search_not_found", 404, "Not found", "The search is not in your history"}
+	kindNoSuchFeed          = ErrorKind{3003, "feed_not_found", 404, "Not found", "The feed does not exist or was revoked"}
+	kindNoSuchJob           = ErrorKind{3004, "job_not_found", 404, "Not found", "The job does not exist, was cancelled or finished too long ago"}
+	kindSearchFailed        = ErrorKind{4000, "search_failed", 422, "Error finding free times", "The search could not be run, such as for an unknown weekday or time"}
+	kindOutOfTerm           = ErrorKind{4001, "out_of_term", 422, "Error finding free times", "There is no timetable for the week asked about"}
+	kindTooManyFeeds        = ErrorKind{4003, "too_many_feeds", 409, "Conflict", "The user holds as many feeds as allowed"}
+	kindNoStreaming         = ErrorKind{4004, "streaming_unsupported", 500, "Internal server error", "The connection cannot stream responses"}
+	kindTooManyJobs         = ErrorKind{4005, "too_many_jobs", 409, "Conflict", "The user has as many jobs running as allowed"}
+	kindFeedsNotSaved       = ErrorKind{4006, "feeds_not_saved", 500, "Internal server error", "The feed or its revocation could not be saved, nothing changed"}
+	kindUpstreamUnavailable = ErrorKind{5000, "upstream_unavailable", 503, "Service unavailable", "No timetable could be fetched from the college website"}
+)
+
+// errorCatalog lists every kind, in code order, for the documentation.
+var errorCatalog = []ErrorKind{
+	kindInvalidRequest, kindValidationFailed, kindInvalidWeek,
+	kindUnauthorized, kindNoSubject, kindInsufficientScope,
+	kindNotFound, kindUnknownRoom, kindNoSuchSearch, kindNoSuchFeed, kindNoSuchJob,
+	kindSearchFailed, kindOutOfTerm, kindTooManyFeeds, kindNoStreaming, kindTooManyJobs, kindFeedsNotSaved,
+	kindUpstreamUnavailable,
+}
+
+var (
+	errNoSuchSearch = e.New("No such search in your history")
+	errNoSuchFeed   = e.New("No such feed")
+	errNoSuchJob    = e.New("No such job")
+	errNoSubject    = e.New("Token has no subject")
+	errNoStreaming  = e.New("Streaming is not supported")
+)
+
+// errorKinds gives the errors that have a kind of their own.
+var errorKinds = map[error]ErrorKind{
+	fft.ErrInvalidWeek:         kindInvalidWeek,
+	errNoSubject:               kindNoSubject,
+	errNoToken:                 kindUnauthorized,
+	fft.ErrUnknownRoom:         kindUnknownRoom,
+	errNoSuchSearch:            kindNoSuchSearch,
+	errNoSuchFeed:              kindNoSuchFeed,
+	errNoSuchJob:               kindNoSuchJob,
+	fft.ErrOutOfTerm:           kindOutOfTerm,
+	fft.ErrTooManyFeeds:        kindTooManyFeeds,
+	errNoStreaming:             kindNoStreaming,
+	fft.ErrTooManyJobs:         kindTooManyJobs,
+	fft.ErrFeedsNotSaved:       kindFeedsNotSaved,
+	fft.ErrUpstreamUnavailable: kindUpstreamUnavailable,
+}
+
+// kindOf is the kind of err, or fallback when err has none of its own.
+func kindOf(err error, fallback ErrorKind) ErrorKind {
+	if _, ok := err.(*ValidationError); ok {
+		return kindValidationFailed
+	}
+	if kind, ok := errorKinds[err]; ok {
+		return kind
+	}
+	return fallback
+}
+
+func newErrResponse(err error, fallback ErrorKind) *ErrResponse {
+	kind := kindOf(err, fallback)
+
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: kind.Status,
+		StatusText:     kind.Title,
+		AppCode:        kind.Code,
+		Type:           kind.Type,
+		ErrorText:      err.Error(),
+	}
+}
+
+// bearerChallenge is the WWW-Authenticate header of RFC 6750, which leaves
+// out the error when no token was sent at all.
+func bearerChallenge(err error, scope string) string {
+	challenge := `Bearer realm="rooms-checker-go"`
+
+	switch {
+	case scope != "":
+		challenge += `, error="insufficient_scope", scope="` + scope + `"`
+	case err != auth.ErrTokenNotFound:
+		challenge += `, error="invalid_token", error_description="` + strings.Replace(err.Error(), `"`, "'", -1) + `"`
+	}
+
+	return challenge
+}
+
+type ErrorCatalogResponse struct {
+	Errors []ErrorKind `json:"errors"`
+}
+
+func (c *ErrorCatalogResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (c *ErrorCatalogResponse) Table() [][]string {
+	records := [][]string{{"code", "type", "status", "title", "description"}}
+	for _, kind := range c.Errors {
+		records = append(records, []string{strconv.FormatInt(kind.Code, 10), kind.Type, strconv.Itoa(kind.Status), kind.Title, kind.Description})
+	}
+	return records
+}
+
+// GET /api/public/errors?format=markdown
+func getErrorCatalog(w http.ResponseWriter, r *http.Request) {
+	render.Render(w, r, &ErrorCatalogResponse{errorCatalog})
+}
@@ -0,0 +1,590 @@
+package main
+
+import (
+	"encoding/json"
+	e "errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	fft "github.com/thailekha/rooms-checker-go/api"
+)
+
+// The GraphQL schema follows the REST routes: rooms, weeks and timetables
+// are public, availability needs a token like /api/private/freetimes.
+
+type roomNode struct {
+	Name     string `json:"name"`
+	Building string `json:"building"`
+}
+
+type weekNode struct {
+	Number  int    `json:"number"`
+	Start   string `json:"start"` // date of the Monday
+	End     string `json:"end"`   // date of the Friday
+	Current bool   `json:"current"`
+}
+
+type eventNode struct {
+	Room     string `json:"room"`
+	Week     int    `json:"week"`
+	Day      string `json:"day"`
+	Time     string `json:"time"`
+	Module   string `json:"module"`
+	Group    string `json:"group"`
+	Lecturer string `json:"lecturer"`
+}
+
+type availabilityNode struct {
+	Room  string     `json:"room"`
+	Week  int        `json:"week"`
+	Day   string     `json:"day"`
+	Time  string     `json:"time"`
+	Free  bool       `json:"free"`
+	Event *eventNode `json:"event"`
+}
+
+var errNoToken = e.New("Sign in to see availability")
+
+// availabilityType picks free or busy slots only.
+var availabilityType = graphql.NewEnum(graphql.EnumConfig{
+	Name: "AvailabilityType",
+	Values: graphql.EnumValueConfigMap{
+		"FREE": {Value: "FREE"},
+		"BUSY": {Value: "BUSY"},
+	},
+})
+
+// availabilityArgs are the arguments of every availability field.
+func availabilityArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"week": {Type: graphql.Int, Description: "teaching week, defaults to the current one"},
+		"day":  {Type: graphql.String, Description: "weekday, every day when left out"},
+		"from": {Type: graphql.String, Description: "first slot, such as 9:15"},
+		"to":   {Type: graphql.String, Description: "last slot, such as 16:15"},
+		"type": {Type: availabilityType, Description: "only free or only busy slots"},
+	}
+}
+
+func newGraphQLSchema() graphql.Schema {
+	event := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Event",
+		Fields: graphql.Fields{
+			"room":     {Type: graphql.NewNonNull(graphql.String)},
+			"week":     {Type: graphql.NewNonNull(graphql.Int)},
+			"day":      {Type: graphql.NewNonNull(graphql.String)},
+			"time":     {Type: graphql.NewNonNull(graphql.String)},
+			"module":   {Type: graphql.NewNonNull(graphql.String)},
+			"group":    {Type: graphql.String},
+			"lecturer": {Type: graphql.String},
+		},
+	})
+
+	availability := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Availability",
+		Fields: graphql.Fields{
+			"room":  {Type: graphql.NewNonNull(graphql.String)},
+			"week":  {Type: graphql.NewNonNull(graphql.Int)},
+			"day":   {Type: graphql.NewNonNull(graphql.String)},
+			"time":  {Type: graphql.NewNonNull(graphql.String)},
+			"free":  {Type: graphql.NewNonNull(graphql.Boolean)},
+			"event": {Type: event, Description: "What the room is booked for, null when free"},
+		},
+	})
+	availabilities := graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(availability)))
+
+	week := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Week",
+		Fields: graphql.Fields{
+			"number":  {Type: graphql.NewNonNull(graphql.Int)},
+			"start":   {Type: graphql.NewNonNull(graphql.String), Description: "Date of the Monday"},
+			"end":     {Type: graphql.NewNonNull(graphql.String), Description: "Date of the Friday"},
+			"current": {Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	timetableArgs := availabilityArgs()
+	room := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Room",
+		Fields: graphql.Fields{
+			"name":     {Type: graphql.NewNonNull(graphql.String)},
+			"building": {Type: graphql.NewNonNull(graphql.String)},
+			"timetable": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(event))),
+				Description: "What the room is booked for",
+				Args:        graphql.FieldConfigArgument{"week": timetableArgs["week"], "day": timetableArgs["day"]},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					snapshot, err := fft.GetTimetable(p.Source.(roomNode).Name, graphQLWeek(p))
+					if err != nil {
+						return nil, err
+					}
+
+					nodes := make([]*eventNode, 0)
+					for _, day := range fft.GetWeekdays() {
+						if stringArg(p, "day") != "" && stringArg(p, "day") != day {
+							continue
+						}
+						for _, slot := range snapshot.Timetable.Days[day] {
+							if !slot.Free && slot.Event != nil {
+								nodes = append(nodes, newEventNode(snapshot.Timetable.Room, snapshot.Timetable.Week, day, slot))
+							}
+						}
+					}
+					return nodes, nil
+				},
+			},
+			"availability": {
+				Type: availabilities,
+				Args: availabilityArgs(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveAvailability(p, []string{p.Source.(roomNode).Name}, "")
+				},
+			},
+		},
+	})
+
+	queryAvailabilityArgs := availabilityArgs()
+	queryAvailabilityArgs["building"] = &graphql.ArgumentConfig{Type: graphql.String}
+	queryAvailabilityArgs["rooms"] = &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"rooms": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(room))),
+				Args: graphql.FieldConfigArgument{"building": {Type: graphql.String}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					nodes := make([]roomNode, 0)
+					for _, room := range fft.GetAllRooms() {
+						if building := stringArg(p, "building"); building == "" || fft.Building(room) == building {
+							nodes = append(nodes, newRoomNode(room))
+						}
+					}
+					return nodes, nil
+				},
+			},
+			"room": {
+				Type: room,
+				Args: graphql.FieldConfigArgument{"name": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if !fft.IsRoom(stringArg(p, "name")) {
+						return nil, nil
+					}
+					return newRoomNode(stringArg(p, "name")), nil
+				},
+			},
+			"weeks": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(week))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					nodes := make([]weekNode, 0)
+					for week := fft.FirstWeek; week <= fft.LastWeek; week++ {
+						nodes = append(nodes, newWeekNode(week))
+					}
+					return nodes, nil
+				},
+			},
+			"week": {
+				Type:        week,
+				Description: "The current week when no number is given",
+				Args:        graphql.FieldConfigArgument{"number": {Type: graphql.Int}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					week, _ := p.Args["number"].(int)
+					if week == 0 {
+						week = graphQLWeek(p)
+					}
+					if week < fft.FirstWeek || week > fft.LastWeek {
+						return nil, fft.ErrOutOfTerm
+					}
+					return newWeekNode(week), nil
+				},
+			},
+			"availability": {
+				Type:        availabilities,
+				Description: "Slots of every room, or of the rooms or building given",
+				Args:        queryAvailabilityArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveAvailability(p, stringsArg(p, "rooms"), stringArg(p, "building"))
+				},
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		panic(err)
+	}
+
+	return schema
+}
+
+// resolveAvailability reads the slots of rooms through the timetable cache,
+// provided the request carries a token.
+func resolveAvailability(p graphql.ResolveParams, rooms []string, building string) (interface{}, error) {
+	if _, ok := p.Context.Value(userCtxKey).(string); !ok {
+		return nil, errNoToken
+	}
+
+	filter := fft.AvailabilityFilter{
+		Week:      graphQLWeek(p),
+		StartTime: stringArg(p, "from"),
+		EndTime:   stringArg(p, "to"),
+		Rooms:     rooms,
+		Building:  building,
+	}
+	if day := stringArg(p, "day"); day != "" {
+		filter.Days = []string{day}
+	}
+	if typ := stringArg(p, "type"); typ != "" {
+		free := typ == "FREE"
+		filter.Free = &free
+	}
+
+	slots, _, err := fft.FindAvailability(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]availabilityNode, 0, len(slots))
+	for _, slot := range slots {
+		node := availabilityNode{Room: slot.Room, Week: slot.Week, Day: slot.Weekday, Time: slot.Time, Free: slot.Free}
+		if slot.Event != nil {
+			node.Event = newEventNode(slot.Room, slot.Week, slot.Weekday, fft.Slot{Time: slot.Time, Event: slot.Event})
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// graphQLWeek is the week argument, or the current week, or the default
+// week outside term.
+func graphQLWeek(p graphql.ResolveParams) int {
+	if week, _ := p.Args["week"].(int); week != 0 {
+		return week
+	}
+	if week, err := fft.WeekAt(time.Now()); err == nil {
+		return week
+	}
+	return fft.DefaultWeek
+}
+
+func stringArg(p graphql.ResolveParams, name string) string {
+	s, _ := p.Args[name].(string)
+	return s
+}
+
+func stringsArg(p graphql.ResolveParams, name string) []string {
+	list, _ := p.Args[name].([]interface{})
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+func newRoomNode(room string) roomNode {
+	return roomNode{Name: room, Building: fft.Building(room)}
+}
+
+func newWeekNode(week int) weekNode {
+	current, _ := fft.WeekAt(time.Now())
+	start := fft.WeekStart(week)
+
+	return weekNode{
+		Number:  week,
+		Start:   start.Format("2006-01-02"),
+		End:     start.AddDate(0, 0, 4).Format("2006-01-02"),
+		Current: week == current,
+	}
+}
+
+func newEventNode(room string, week int, day string, slot fft.Slot) *eventNode {
+	return &eventNode{
+		Room:     room,
+		Week:     week,
+		Day:      day,
+		Time:     slot.Time,
+		Module:   slot.Event.Module,
+		Group:    slot.Event.Group,
+		Lecturer: slot.Event.Lecturer,
+	}
+}
+
+var graphQLSchema = newGraphQLSchema()
+
+// Anyone can query /api/graphql, so queries are bounded before they are
+// validated or run: a fragment spread twice in each of n nested fragments
+// selects 2^n fields.
+const (
+	maxGraphQLBody  = 64 << 10 // bytes of a POST body or GET query
+	maxGraphQLDepth = 15       // nested selections, enough for GraphiQL's introspection query
+	maxGraphQLNodes = 1000     // fields selected, fragments expanded
+)
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// POST /api/graphql, or GET /api/graphql?query=&variables=&operationName=
+func serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	req := graphQLRequest{}
+
+	if r.Method == "GET" {
+		q := r.URL.Query()
+		req.Query, req.OperationName = q.Get("query"), q.Get("operationName")
+		if variables := q.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				render.Render(w, r, ErrInvalidRequest(e.New("variables must be a JSON object")))
+				return
+			}
+		}
+	} else if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLBody)).Decode(&req); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	if len(req.Query) > maxGraphQLBody {
+		render.Render(w, r, ErrInvalidRequest(errQueryTooLong))
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+	if err != nil {
+		renderGraphQLErrors(w, r, gqlerrors.FormatErrors(err))
+		return
+	}
+	if err := checkGraphQLSize(doc); err != nil {
+		renderGraphQLErrors(w, r, gqlerrors.FormatErrors(err))
+		return
+	}
+	if validation := graphql.ValidateDocument(&graphQLSchema, doc, nil); !validation.IsValid {
+		renderGraphQLErrors(w, r, validation.Errors)
+		return
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        graphQLSchema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       r.Context(),
+	})
+	codeGraphQLErrors(result.Errors)
+	render.JSON(w, r, result)
+}
+
+// renderGraphQLErrors answers a query that could not be run with its
+// errors only, as the spec leaves data out when execution did not start.
+func renderGraphQLErrors(w http.ResponseWriter, r *http.Request, errs []gqlerrors.FormattedError) {
+	codeGraphQLErrors(errs)
+	render.JSON(w, r, map[string]interface{}{"errors": errs})
+}
+
+// codeGraphQLErrors adds the catalog code and type to the extensions of
+// each error: invalid_request for errors of the query itself, and the
+// resolver's kind for errors of a field.
+func codeGraphQLErrors(errs []gqlerrors.FormattedError) {
+	for i, err := range errs {
+		kind := kindInvalidRequest
+		if located, ok := err.OriginalError().(*gqlerrors.Error); ok && located.OriginalError != nil && len(err.Path) > 0 {
+			kind = kindOf(located.OriginalError, kindSearchFailed)
+		}
+		errs[i].Extensions = map[string]interface{}{"code": kind.Code, "type": kind.Type}
+	}
+}
+
+var errQueryTooLong = e.New("Query must not be longer than " + strconv.Itoa(maxGraphQLBody) + " bytes")
+
+// checkGraphQLSize rejects documents with an operation nested deeper than
+// maxGraphQLDepth or selecting more than maxGraphQLNodes fields. Each
+// fragment is sized once, however often it is spread.
+func checkGraphQLSize(doc *ast.Document) error {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	s := &graphQLSizer{fragments: fragments, sized: map[string]graphQLSize{}, sizing: map[string]bool{}}
+	for _, def := range doc.Definitions {
+		if operation, ok := def.(*ast.OperationDefinition); ok {
+			size, err := s.selections(operation.SelectionSet)
+			if err != nil {
+				return err
+			}
+			if size.depth > maxGraphQLDepth {
+				return fmt.Errorf("Query is nested %d levels deep, at most %d are allowed", size.depth, maxGraphQLDepth)
+			}
+			if size.nodes > maxGraphQLNodes {
+				return fmt.Errorf("Query selects more than %d fields", maxGraphQLNodes)
+			}
+		}
+	}
+	return nil
+}
+
+type graphQLSize struct {
+	depth int
+	nodes int // saturates at maxGraphQLNodes + 1
+}
+
+type graphQLSizer struct {
+	fragments map[string]*ast.FragmentDefinition
+	sized     map[string]graphQLSize
+	sizing    map[string]bool // fragments being sized, to catch cycles
+}
+
+func (s *graphQLSizer) selections(set *ast.SelectionSet) (graphQLSize, error) {
+	total := graphQLSize{}
+	if set == nil {
+		return total, nil
+	}
+
+	for _, selection := range set.Selections {
+		var size graphQLSize
+		var err error
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			size, err = s.selections(selection.SelectionSet)
+			size.depth++
+			size.nodes++
+		case *ast.InlineFragment:
+			size, err = s.selections(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			size, err = s.fragment(selection.Name.Value)
+		}
+		if err != nil {
+			return total, err
+		}
+
+		if size.depth > total.depth {
+			total.depth = size.depth
+		}
+		total.nodes += size.nodes
+		if total.nodes > maxGraphQLNodes {
+			total.nodes = maxGraphQLNodes + 1
+		}
+	}
+	return total, nil
+}
+
+// fragment sizes the fragment name, leaving unknown fragments to validation.
+func (s *graphQLSizer) fragment(name string) (graphQLSize, error) {
+	if size, ok := s.sized[name]; ok {
+		return size, nil
+	}
+	fragment, ok := s.fragments[name]
+	if !ok {
+		return graphQLSize{}, nil
+	}
+	if s.sizing[name] {
+		return graphQLSize{}, fmt.Errorf("Cannot spread fragment %q within itself", name)
+	}
+
+	s.sizing[name] = true
+	size, err := s.selections(fragment.SelectionSet)
+	s.sizing[name] = false
+
+	if err == nil {
+		s.sized[name] = size
+	}
+	return size, err
+}
+
+// GET /api/graphql/schema
+func getGraphQLSchema(w http.ResponseWriter, r *http.Request) {
+	render.PlainText(w, r, graphQLSDL(graphQLSchema))
+}
+
+// graphQLSDL prints the types of schema in the schema definition language,
+// Query first, leaving out the built-in scalars and introspection types.
+func graphQLSDL(schema graphql.Schema) string {
+	var b strings.Builder
+
+	var enums, objects []string
+	for name, typ := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		switch typ.(type) {
+		case *graphql.Enum:
+			enums = append(enums, name)
+		case *graphql.Object:
+			if name != schema.QueryType().Name() {
+				objects = append(objects, name)
+			}
+		}
+	}
+	sort.Strings(enums)
+	sort.Strings(objects)
+
+	for _, name := range enums {
+		fmt.Fprintf(&b, "enum %s {\n", name)
+		for _, value := range schema.Type(name).(*graphql.Enum).Values() {
+			fmt.Fprintf(&b, "  %s\n", value.Name)
+		}
+		b.WriteString("}\n\n")
+	}
+
+	for _, name := range append([]string{schema.QueryType().Name()}, objects...) {
+		object := schema.Type(name).(*graphql.Object)
+		writeSDLDescription(&b, "", object.Description())
+		fmt.Fprintf(&b, "type %s {\n", name)
+
+		fields := object.Fields()
+		names := make([]string, 0, len(fields))
+		for fieldName := range fields {
+			names = append(names, fieldName)
+		}
+		sort.Strings(names)
+
+		for _, fieldName := range names {
+			field := fields[fieldName]
+			writeSDLDescription(&b, "  ", field.Description)
+			fmt.Fprintf(&b, "  %s%s: %s\n", fieldName, argumentsSDL(field.Args), field.Type)
+		}
+		b.WriteString("}\n\n")
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func argumentsSDL(args []*graphql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	sorted := append([]*graphql.Argument{}, args...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name() < sorted[j].Name() })
+
+	parts := make([]string, 0, len(sorted))
+	for _, arg := range sorted {
+		part := arg.Name() + ": " + arg.Type.String()
+		if arg.Description() != "" {
+			part = fmt.Sprintf("%q ", arg.Description()) + part
+		}
+		parts = append(parts, part)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func writeSDLDescription(b *strings.Builder, indent string, description string) {
+	if description != "" {
+		fmt.Fprintf(b, "%s%q\n", indent, description)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	fft "github.com/thailekha/rooms-checker-go/api"
+)
+
+// spreadTwice is { ...F0 } where each of n fragments spreads the next one
+// twice, selecting 2^n fields once expanded.
+func spreadTwice(n int) string {
+	var b strings.Builder
+	b.WriteString("{ ...F0 }")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, " fragment F%d on Query { ...F%d ...F%d }", i, i+1, i+1)
+	}
+	fmt.Fprintf(&b, " fragment F%d on Query { weeks { number } }", n)
+	return b.String()
+}
+
+func TestServeGraphQL(t *testing.T) {
+	cases := []struct {
+		name   string
+		method string
+		query  string
+		user   string
+		want   string
+	}{
+		{"answers public queries", "POST", `{ room(name: \"IT101\") { name building } }`, "", `{"data":{"room":{"building":"IT","name":"IT101"}}}`},
+		{"answers GET queries", "GET", `{ room(name: "IT101") { name } }`, "", `{"data":{"room":{"name":"IT101"}}}`},
+		{"answers queries with fragments", "GET", `{ room(name: "IT101") { ...names } } fragment names on Room { name }`, "", `{"data":{"room":{"name":"IT101"}}}`},
+		{"answers introspection", "GET", `{ __schema { queryType { name } } __type(name: "Availability") { kind } }`, "", `{"data":{"__schema":{"queryType":{"name":"Query"}},"__type":{"kind":"OBJECT"}}}`},
+		{"answers null data for availability without a token", "POST", `{ availability(day: \"monday\") { room } }`, "", `{"data":null,"errors":[{"message":"Sign in to see availability","locations":[{"line":1,"column":3}],"path":["availability"],"extensions":{"code":2000,"type":"unauthorized"}}]}`},
+		{"codes resolver errors from the catalog", "GET", `{ week(number: 99) { number } }`, "", `{"data":{"week":null},"errors":[{"message":"` + fft.ErrOutOfTerm.Error() + `","locations":[{"line":1,"column":3}],"path":["week"],"extensions":{"code":4001,"type":"out_of_term"}}]}`},
+		{"leaves data out of invalid queries", "POST", `{ lecturers { name } }`, "", `{"errors":[{"message":"Cannot query field \"lecturers\" on type \"Query\".","locations":[{"line":1,"column":3}],"extensions":{"code":1000,"type":"invalid_request"}}]}`},
+		{"leaves data out of unparsable queries", "GET", `{ room(`, "", `{"errors":[{"message":"Syntax Error GraphQL request`},
+		{"rejects queries nested too deep", "GET", "{ __schema { types { fields { type " + strings.Repeat("{ ofType ", 11) + "{ name }" + strings.Repeat(" }", 11) + " } } } }", "", `{"errors":[{"message":"Query is nested 16 levels deep, at most 15 are allowed"`},
+		{"rejects fragments spread within themselves", "GET", `{ ...A } fragment A on Query { ...B } fragment B on Query { ...A }`, "", `{"errors":[{"message":"Cannot spread fragment \"A\" within itself"`},
+		{"rejects queries selecting too many fields", "GET", spreadTwice(22), "", `{"errors":[{"message":"Query selects more than 1000 fields"`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := newRequest("POST", "/", c.user, `{"query":"`+c.query+`"}`)
+			if c.method == "GET" {
+				r = newRequest("GET", "/?query="+url.QueryEscape(c.query), c.user, "")
+			}
+
+			start := time.Now()
+			w := serve(serveGraphQL, "/", r)
+			if w.Code != 200 || !strings.HasPrefix(w.Body.String(), c.want) {
+				t.Errorf("got %d %s, want %s", w.Code, w.Body, c.want)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("took %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestServeGraphQLTooLarge(t *testing.T) {
+	query := "{ weeks { number } }" + strings.Repeat(" ", maxGraphQLBody)
+
+	cases := []struct {
+		name string
+		r    func() *httptest.ResponseRecorder
+	}{
+		{"rejects long bodies", func() *httptest.ResponseRecorder {
+			return serve(serveGraphQL, "/", newRequest("POST", "/", "", `{"query":"`+query+`"}`))
+		}},
+		{"rejects long GET queries", func() *httptest.ResponseRecorder {
+			return serve(serveGraphQL, "/", newRequest("GET", "/?query="+url.QueryEscape(query), "", ""))
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if w := c.r(); w.Code != 400 || !strings.Contains(w.Body.String(), `"type":"invalid_request"`) {
+				t.Errorf("got %d %s, want 400 invalid_request", w.Code, w.Body)
+			}
+		})
+	}
+}
+
+func TestGraphQLSchema(t *testing.T) {
+	w := serve(getGraphQLSchema, "/", newRequest("GET", "/", "", ""))
+
+	for _, want := range []string{
+		"enum AvailabilityType {\n  FREE\n  BUSY\n}",
+		"type Query {\n",
+		`  availability(building: String, "weekday, every day when left out" day: String, "first slot, such as 9:15" from: String, rooms: [String!], "last slot, such as 16:15" to: String, "only free or only busy slots" type: AvailabilityType, "teaching week, defaults to the current one" week: Int): [Availability!]!`,
+		"type Room {\n  availability(",
+		"  \"What the room is booked for, null when free\"\n  event: Event\n",
+	} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("schema lacks %q:\n%s", want, w.Body)
+		}
+	}
+	if strings.Contains(w.Body.String(), "__") {
+		t.Errorf("schema has introspection types:\n%s", w.Body)
+	}
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+
+	fft "github.com/thailekha/rooms-checker-go/api"
+)
+
+// operation is one endpoint of the v2 API. The v2 router and its OpenAPI
+// document are both built from the same operations, so a handler cannot be
+// mounted without being described.
+type operation struct {
+	Method   string
+	Pattern  string // relative to /api/v2, in chi syntax
+	Summary  string
+	Handler  http.HandlerFunc
+	Auth     bool   // needs a bearer token
+	Scope    string // scope the token needs on top, if any
+	Params   []param
+	Request  interface{} // decoded request body, nil when there is none
+	Response interface{} // JSON response body, nil when there is none
+	Stream   bool        // responds with an SSE or NDJSON stream
+	Formats  []string    // media types the response comes in besides JSON
+	Created  bool        // succeeds with 201 rather than 200
+	Accepted bool        // succeeds with 202, the work going on in the background
+	Cached   bool        // answers conditional requests, see notModified
+}
+
+// param is a query parameter; path parameters come from the pattern.
+type param struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+func v2Operations() []operation {
+	historyParams := []param{
+		{"from", "string", "RFC3339 time of the oldest search"},
+		{"to", "string", "RFC3339 time of the newest search"},
+		{"room", "string", "only searches that included this room"},
+		{"weekday", "string", "only searches for this weekday"},
+		{"cursor", "string", "nextCursor of the previous page"},
+		{"limit", "integer", "page size, 1 to 500"},
+	}
+
+	searchParams := []param{
+		{"format", "string", "ics for an iCalendar of the free blocks, csv or markdown for a table of rooms by time"},
+	}
+
+	return []operation{
+		{Method: "GET", Pattern: "/public/rooms", Summary: "List the rooms that can be searched",
+			Handler: getAllRooms, Response: AllRoomsResponse{}, Cached: true},
+		{Method: "GET", Pattern: "/public/rooms/{room}/timetable", Summary: "Timetable of a room for a week",
+			Handler: getTimetable, Response: TimetableResponse{}, Formats: []string{"text/calendar"}, Cached: true,
+			Params: []param{
+				{"week", "integer", "teaching week, defaults to the current one"},
+				{"format", "string", "ics for an iCalendar of the bookings"},
+			}},
+		{Method: "GET", Pattern: "/public/free-now", Summary: "Rooms free right now",
+			Handler: getFreeNow, Response: FreeNowResponse{},
+			Params: []param{
+				{"for", "string", "how long the room must stay free, such as 60m"},
+				{"at", "string", "RFC3339 time to ask about instead of now"},
+			}},
+		{Method: "GET", Pattern: "/public/feeds/{token}", Summary: "iCalendar feed of free rooms, authenticated by its token",
+			Handler: getFeed, Formats: []string{"text/calendar"}, Cached: true},
+		{Method: "GET", Pattern: "/public/errors", Summary: "Catalog of the error codes and types",
+			Handler: getErrorCatalog, Response: ErrorCatalogResponse{}, Formats: []string{"text/csv", "text/markdown"},
+			Params: []param{{"format", "string", "csv or markdown for a table of the errors"}}},
+		{Method: "GET", Pattern: "/private/freetimes", Summary: "Find free times of rooms, redirecting to the canonical query first",
+			Handler: getFreeTimes, Auth: true, Response: FreeTimesResponse{}, Cached: true,
+			Formats: []string{"text/calendar", "text/csv", "text/markdown"},
+			Params: append([]param{
+				{"day", "string", "weekday, such as monday"},
+				{"week", "integer", "teaching week, defaults to " + strconv.Itoa(fft.DefaultWeek)},
+				{"from", "string", "first slot, such as 9:15"},
+				{"to", "string", "last slot, such as 16:15"},
+				{"rooms", "string", "rooms to search, repeated or comma separated"},
+				{"type", "string", "any (default), all or atleast rooms free at the same time"},
+				{"minRooms", "integer", "how many rooms must be free at once when type is atleast"},
+				{"minDuration", "integer", "minutes, only contiguous free blocks this long"},
+				{"explain", "boolean", "tell for each room and slot whether it is free"},
+			}, searchParams...)},
+		{Method: "POST", Pattern: "/private/freetimes", Summary: "Find free times of rooms",
+			Handler: checkFreeTimes, Auth: true, Request: FreeTimesRequest{}, Response: FreeTimesResponse{},
+			Formats: []string{"text/calendar", "text/csv", "text/markdown"}, Params: searchParams},
+		{Method: "POST", Pattern: "/private/freetimes/batch", Summary: "Run several searches, answering each in order with its result or error",
+			Handler: checkFreeTimesBatch, Auth: true, Request: []FreeTimesRequest{}, Response: []BatchItemResponse{}},
+		{Method: "POST", Pattern: "/private/freetimes/stream", Summary: "Find free times of rooms, one event per room",
+			Handler: streamFreeTimes, Auth: true, Request: FreeTimesRequest{}, Stream: true},
+		{Method: "POST", Pattern: "/private/matrix", Summary: "Availability of rooms across days and times",
+			Handler: checkMatrix, Auth: true, Request: MatrixRequest{}, Response: MatrixResponse{},
+			Formats: []string{"text/csv", "text/markdown"}, Params: []param{{"format", "string", "csv or markdown for a table of rooms by day and time"}}},
+		{Method: "GET", Pattern: "/private/me/history", Summary: "Your own searches",
+			Handler: getMyHistory, Auth: true, Response: HistoryResponse{}, Params: historyParams},
+		{Method: "POST", Pattern: "/private/me/history/{id}/rerun", Summary: "Run one of your searches again",
+			Handler: rerunMySearch, Auth: true, Response: FreeTimesResponse{},
+			Formats: []string{"text/calendar", "text/csv", "text/markdown"}, Params: searchParams},
+		{Method: "DELETE", Pattern: "/private/me/history/{id}", Summary: "Forget one of your searches",
+			Handler: deleteMySearch, Auth: true},
+		{Method: "GET", Pattern: "/private/me/feeds", Summary: "Your calendar feeds",
+			Handler: getMyFeeds, Auth: true, Response: FeedsResponse{}},
+		{Method: "POST", Pattern: "/private/me/feeds", Summary: "Create a calendar feed of rooms",
+			Handler: createMyFeed, Auth: true, Request: FeedRequest{}, Response: FeedResponse{}, Created: true},
+		{Method: "DELETE", Pattern: "/private/me/feeds/{id}", Summary: "Revoke a calendar feed",
+			Handler: revokeMyFeed, Auth: true},
+		{Method: "POST", Pattern: "/private/jobs", Summary: "Start a free times search in the background",
+			Handler: createJob, Auth: true, Request: FreeTimesRequest{}, Response: JobResponse{}, Accepted: true},
+		{Method: "GET", Pattern: "/private/jobs/{id}", Summary: "Status, progress and result of a job",
+			Handler: getJob, Auth: true, Response: JobResponse{}},
+		{Method: "DELETE", Pattern: "/private/jobs/{id}", Summary: "Cancel a job",
+			Handler: cancelJob, Auth: true},
+		{Method: "GET", Pattern: "/limitedprivate/history", Summary: "Searches of every user",
+			Handler: getHistory, Auth: true, Scope: "read:history", Response: HistoryResponse{},
+			Params: append(historyParams, param{"user", "string", "only searches by this user"})},
+		{Method: "GET", Pattern: "/analytics/usage", Summary: "Usage statistics of a period",
+			Handler: getUsage, Auth: true, Scope: "read:analytics", Response: UsageResponse{}, Formats: []string{"text/csv", "text/markdown"},
+			Params: []param{
+				{"from", "string", "RFC3339 start of the period"},
+				{"to", "string", "RFC3339 end of the period"},
+				{"format", "string", "csv or markdown for a table"},
+			}},
+	}
+}
+
+// v2Router mounts ops and serves their OpenAPI document at /openapi.json.
+// TestRoutesMatchSpec checks the document against every route the server
+// mounts, v1 and v2.
+func v2Router(ops []operation) chi.Router {
+	r := chi.NewRouter()
+
+	for _, op := range ops {
+		h := http.Handler(op.Handler)
+		if op.Scope != "" {
+			h = validateJwtTokenAndScope(validator, op.Scope)(h)
+		} else if op.Auth {
+			h = validateJwtToken(validator)(h)
+		}
+		r.Method(op.Method, op.Pattern, h)
+	}
+
+	spec := openAPI(ops)
+	r.Get("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, spec)
+	})
+
+	return r
+}
+
+//==============================
+// OpenAPI document (start)
+//==============================
+
+type OpenAPI struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       map[string]string               `json:"info"`
+	Servers    []map[string]string             `json:"servers"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Operation struct {
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *Body                 `json:"requestBody,omitempty"`
+	Responses   map[string]Body       `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Body struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema           `json:"schemas"`
+	SecuritySchemes map[string]map[string]string `json:"securitySchemes"`
+}
+
+var pathParam = regexp.MustCompile(`{([^}]+)}`)
+
+// openAPI describes ops, deriving every schema from the Go types the
+// handlers decode and render.
+func openAPI(ops []operation) *OpenAPI {
+	schemas := map[string]*Schema{}
+	errBody := Body{
+		Description: "Error",
+		Content:     map[string]MediaType{"application/json": {schemaOf(reflect.TypeOf(ErrResponse{}), schemas)}},
+	}
+
+	spec := &OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    map[string]string{"title": "Rooms Checker", "version": "2"},
+		Servers: []map[string]string{{"url": "/api/v2"}},
+		Paths:   map[string]map[string]Operation{},
+		Components: Components{
+			Schemas: schemas,
+			SecuritySchemes: map[string]map[string]string{
+				"bearer": {"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	}
+
+	for _, op := range ops {
+		o := Operation{
+			Summary:   op.Summary,
+			Responses: map[string]Body{"default": errBody},
+		}
+
+		if op.Auth {
+			o.Security = []map[string][]string{{"bearer": {}}}
+			o.Responses["401"] = Body{Description: "Missing or invalid token, see WWW-Authenticate", Content: errBody.Content}
+		}
+		if op.Scope != "" {
+			o.Description = "Needs the " + op.Scope + " scope."
+			o.Responses["403"] = Body{Description: "The token lacks the " + op.Scope + " scope", Content: errBody.Content}
+		}
+
+		for _, m := range pathParam.FindAllStringSubmatch(op.Pattern, -1) {
+			o.Parameters = append(o.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		for _, p := range op.Params {
+			o.Parameters = append(o.Parameters, Parameter{Name: p.Name, In: "query", Description: p.Description, Schema: &Schema{Type: p.Type}})
+		}
+
+		if op.Request != nil {
+			o.RequestBody = &Body{
+				Required: true,
+				Content:  map[string]MediaType{"application/json": {schemaOf(reflect.TypeOf(op.Request), schemas)}},
+			}
+		}
+
+		switch {
+		case op.Stream:
+			o.Responses["200"] = Body{
+				Description: "One room event per room, then a summary event",
+				Content: map[string]MediaType{
+					"text/event-stream":    {&Schema{Type: "string"}},
+					"application/x-ndjson": {&Schema{Type: "string"}},
+				},
+			}
+		case op.Response != nil || len(op.Formats) > 0:
+			content := map[string]MediaType{}
+			if op.Response != nil {
+				content["application/json"] = MediaType{schemaOf(reflect.TypeOf(op.Response), schemas)}
+			}
+			for _, format := range op.Formats {
+				content[format] = MediaType{&Schema{Type: "string"}}
+			}
+			if op.Created {
+				o.Responses["201"] = Body{Description: "Created", Content: content}
+			} else if op.Accepted {
+				o.Responses["202"] = Body{Description: "Accepted", Content: content}
+			} else {
+				o.Responses["200"] = Body{Description: "OK", Content: content}
+			}
+		default:
+			o.Responses["204"] = Body{Description: "No Content"}
+		}
+
+		if op.Cached {
+			for _, header := range []string{"If-None-Match", "If-Modified-Since"} {
+				o.Parameters = append(o.Parameters, Parameter{Name: header, In: "header", Schema: &Schema{Type: "string"}})
+			}
+			o.Responses["304"] = Body{Description: "Not Modified, the timetables behind the response have not changed"}
+		}
+
+		if spec.Paths[op.Pattern] == nil {
+			spec.Paths[op.Pattern] = map[string]Operation{}
+		}
+		spec.Paths[op.Pattern][strings.ToLower(op.Method)] = o
+	}
+
+	return spec
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaOf returns the schema of t the way encoding/json would encode it,
+// adding named structs to schemas and referring to them.
+func schemaOf(t reflect.Type, schemas map[string]*Schema) *Schema {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := *schemaOf(t.Elem(), schemas)
+		if s.Ref != "" {
+			return &s
+		}
+		s.Nullable = true
+		return &s
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaOf(t.Elem(), schemas)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaOf(t.Elem(), schemas)}
+	case reflect.Struct:
+		ref := &Schema{Ref: "#/components/schemas/" + t.Name()}
+		if _, ok := schemas[t.Name()]; ok {
+			return ref
+		}
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		schemas[t.Name()] = s
+		addProperties(s, t, schemas)
+		return ref
+	}
+
+	panic(fmt.Sprintf("openapi: no schema for %s", t))
+}
+
+// addProperties adds the JSON fields of t to s, flattening embedded structs
+// like encoding/json does.
+func addProperties(s *Schema, t reflect.Type, schemas map[string]*Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+
+		if name == "-" || f.PkgPath != "" {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			addProperties(s, f.Type, schemas)
+			continue
+		}
+		if name == "" {
+			// untagged request fields are matched case-insensitively
+			name = strings.ToLower(f.Name[:1]) + f.Name[1:]
+		}
+
+		s.Properties[name] = schemaOf(f.Type, schemas)
+	}
+}
+
+//==============================
+// OpenAPI document (end)
+//==============================
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+)
+
+// unversioned are the routes that are neither part of the API nor of its
+// OpenAPI document.
+var unversioned = []string{
+	"GET /",
+	"GET /rooms/{room}",
+	"* /static/*", // any method
+	"GET /api/graphql",
+	"POST /api/graphql",
+	"GET /api/graphql/schema",
+	"GET /api/v2/openapi.json",
+}
+
+// TestRoutesMatchSpec walks the router main serves and fails on any route
+// the served OpenAPI document does not describe, under /api as well as
+// /api/v2, and on any operation the document describes that is not served.
+func TestRoutesMatchSpec(t *testing.T) {
+	router := newRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/openapi.json", nil))
+
+	var spec OpenAPI
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil || w.Code != 200 {
+		t.Fatalf("GET /api/v2/openapi.json: %d %v", w.Code, err)
+	}
+
+	expected := map[string]bool{}
+	for _, route := range unversioned {
+		expected[route] = true
+	}
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			for _, prefix := range []string{"/api", "/api/v2"} {
+				expected[strings.ToUpper(method)+" "+prefix+path] = true
+			}
+		}
+	}
+
+	var drift []string
+	served := map[string]bool{}
+	err := chi.Walk(router, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		// mount points show as /*/, and a subrouter's "/" serves its mount
+		// point
+		route = strings.Replace(route, "/*/", "/", -1)
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+
+		key := method + " " + route
+		switch {
+		case expected["* "+route]:
+			served["* "+route] = true
+		case expected[key]:
+			delete(expected, key)
+		default:
+			drift = append(drift, key+" is served but not documented")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key := range served {
+		delete(expected, key)
+	}
+	for key := range expected {
+		drift = append(drift, key+" is documented but not served")
+	}
+
+	sort.Strings(drift)
+	for _, d := range drift {
+		t.Error(d)
+	}
+}
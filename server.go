@@ -1,18 +1,32 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
 	e "errors"
 	"fmt"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	auth "github.com/auth0-community/go-auth0"
 	"github.com/go-chi/chi"
 	cors "github.com/go-chi/cors"
 	"github.com/go-chi/render"
-	fft "github.com/thailekha/findfreetimes"
+	fft "github.com/thailekha/rooms-checker-go/api"
 	"gopkg.in/square/go-jose.v2"
 	"gopkg.in/square/go-jose.v2/jwt"
 )
@@ -20,6 +34,9 @@ import (
 var validator *auth.JWTValidator
 var port string
 
+// the room list is built into the binary, so it last changed when it started
+var startedAt = time.Now()
+
 func main() {
 	port = os.Getenv("PORT")
 
@@ -27,27 +44,92 @@ func main() {
 		log.Fatal("$PORT must be set")
 	}
 
+	durationFromEnv("CACHE_TTL", &fft.CacheTTL)
+	durationFromEnv("HISTORY_RETENTION", &fft.HistoryRetention)
+	intFromEnv("HISTORY_MAX_ENTRIES", &fft.HistoryMaxEntries)
+	durationFromEnv("JOB_RETENTION", &fft.JobRetention)
+	if secret := os.Getenv("FEED_SECRET"); secret != "" {
+		// feeds outlive the server, so their revocations must as well
+		fft.FeedSecret = []byte(secret)
+		fft.FeedsFile = "feeds.json"
+		if file := os.Getenv("FEEDS_FILE"); file != "" {
+			fft.FeedsFile = file
+		}
+	}
+	if err := fft.LoadFeeds(); err != nil {
+		log.Fatal("reading feeds: ", err)
+	}
+
+	render.Decode = decodeStrict
+	render.Respond = respond
 	validator = getValidator()
 
+	http.ListenAndServe(":"+port, newRouter())
+}
+
+// newRouter mounts every route of the server, the v1 API, its /api/v2 twin
+// and the HTML pages.
+func newRouter() chi.Router {
 	r := chi.NewRouter()
 
 	r.Use(getCors().Handler)
 
+	r.Get("/", getSearchPage)
+	r.Get("/rooms/{room}", getTimetablePage)
+	r.Handle("/static/*", staticFiles())
+
 	r.Route("/api/public", func(r chi.Router) {
 		r.Get("/rooms", getAllRooms)
+		r.Get("/rooms/{room}/timetable", getTimetable)
+		r.Get("/free-now", getFreeNow)
+		r.Get("/feeds/{token}", getFeed)
+		r.Get("/errors", getErrorCatalog)
 	})
 
 	r.Route("/api/private", func(r chi.Router) {
 		r.Use(validateJwtToken(validator))
+		r.Get("/freetimes", getFreeTimes)
 		r.Post("/freetimes", checkFreeTimes)
+		r.Post("/freetimes/stream", streamFreeTimes)
+		r.Post("/freetimes/batch", checkFreeTimesBatch)
+		r.Post("/matrix", checkMatrix)
+		r.Route("/me/history", func(r chi.Router) {
+			r.Get("/", getMyHistory)
+			r.Post("/{id}/rerun", rerunMySearch)
+			r.Delete("/{id}", deleteMySearch)
+		})
+		r.Route("/jobs", func(r chi.Router) {
+			r.Post("/", createJob)
+			r.Get("/{id}", getJob)
+			r.Delete("/{id}", cancelJob)
+		})
+		r.Route("/me/feeds", func(r chi.Router) {
+			r.Get("/", getMyFeeds)
+			r.Post("/", createMyFeed)
+			r.Delete("/{id}", revokeMyFeed)
+		})
 	})
 
 	r.Route("/api/limitedprivate", func(r chi.Router) {
-		r.Use(validateJwtTokenAndScope(validator))
+		r.Use(validateJwtTokenAndScope(validator, "read:history"))
 		r.Get("/history", getHistory)
 	})
 
-	http.ListenAndServe(":"+port, r)
+	r.Route("/api/analytics", func(r chi.Router) {
+		r.Use(validateJwtTokenAndScope(validator, "read:analytics"))
+		r.Get("/usage", getUsage)
+	})
+
+	r.Route("/api/graphql", func(r chi.Router) {
+		r.Use(optionalJwtToken(validator))
+		r.Get("/", serveGraphQL)
+		r.Post("/", serveGraphQL)
+		r.Get("/schema", getGraphQLSchema)
+	})
+
+	r.Mount("/api/v2", v2Router(v2Operations()))
+
+	return r
 }
 
 //==============================
@@ -56,230 +138,1794 @@ func main() {
 
 // GET /api/public/rooms
 func getAllRooms(w http.ResponseWriter, r *http.Request) {
+	if notModified(w, r, strings.Join(fft.GetAllRooms(), ","), fft.Freshness{Modified: startedAt}) {
+		return
+	}
 	render.Render(w, r, NewAllRoomsResponse(fft.GetAllRooms()))
 }
 
-// GET /api/limitedprivate/history
-func getHistory(w http.ResponseWriter, r *http.Request) {
-	render.Render(w, r, NewHistoryResponse(fft.GetHistory()))
+// GET /api/public/rooms/{room}/timetable?week=&format=ics
+// Conditional on the snapshot's version, see notModified.
+func getTimetable(w http.ResponseWriter, r *http.Request) {
+	week, err := weekParam(r)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	snapshot, fftErr := fft.GetTimetable(chi.URLParam(r, "room"), week)
+
+	switch {
+	case fftErr == nil && notModified(w, r, responseFormat(r), snapshotFreshness(snapshot)):
+	case fftErr == nil && wantsCalendar(r):
+		tt := snapshot.Timetable
+		writeCalendar(w, tt.Room+"-w"+strconv.Itoa(tt.Week)+".ics", tt.Room+" week "+strconv.Itoa(tt.Week), fft.TimetableEvents(tt))
+	case fftErr == nil:
+		render.Render(w, r, NewTimetableResponse(snapshot))
+	case fftErr == fft.ErrUnknownRoom:
+		render.Render(w, r, ErrNotFound(fftErr))
+	case fftErr == fft.ErrUpstreamUnavailable:
+		render.Render(w, r, ErrUnavailable(fftErr))
+	default:
+		render.Render(w, r, ErrInvalidRequest(fftErr))
+	}
 }
 
-// POST /api/private/freetimes
-func checkFreeTimes(w http.ResponseWriter, r *http.Request) {
-	data := &FreeTimesRequest{}
-	if err := render.Bind(r, data); err != nil {
+// GET /api/public/free-now?for=60m&at=
+func getFreeNow(w http.ResponseWriter, r *http.Request) {
+	var minFree time.Duration
+
+	if value := r.URL.Query().Get("for"); value != "" {
+		var err error
+		if minFree, err = time.ParseDuration(value); err != nil || minFree < 0 {
+			render.Render(w, r, ErrInvalidRequest(e.New("for must be a duration such as 60m")))
+			return
+		}
+	}
+
+	now, err := timeParam(r, "at", time.Now())
+	if err != nil {
 		render.Render(w, r, ErrInvalidRequest(err))
 		return
 	}
 
-	roomTimes, fftErr := fft.Find(data.Weekday, data.StartTime, data.EndTime, data.Rooms)
+	result, fftErr := fft.FreeNow(now, minFree)
 
-	if fftErr != nil {
+	switch fftErr {
+	case nil:
+		render.Render(w, r, NewFreeNowResponse(now, result))
+	case fft.ErrUpstreamUnavailable:
+		render.Render(w, r, ErrUnavailable(fftErr))
+	default:
 		render.Render(w, r, ErrFFT(fftErr))
+	}
+}
+
+// GET /api/limitedprivate/history?from=&to=&user=&room=&weekday=&cursor=&limit=
+func getHistory(w http.ResponseWriter, r *http.Request) {
+	filter, err := historyFilter(r)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
 		return
 	}
 
-	render.Render(w, r, NewFreeTimesResponse(roomTimes))
+	render.Render(w, r, NewHistoryResponse(fft.QueryHistory(filter)))
 }
 
-//==============================
-// endpoints (end)
-//==============================
+// GET /api/private/me/history?from=&to=&room=&weekday=&cursor=&limit=
+func getMyHistory(w http.ResponseWriter, r *http.Request) {
+	filter, err := historyFilter(r)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
 
-//==============================
-// middleware configs (start)
-//==============================
+	filter.User = userFromContext(r)
+	if filter.User == "" {
+		render.Render(w, r, ErrUnauthorizedRequest(errNoSubject))
+		return
+	}
 
-func getValidator() *auth.JWTValidator {
-	client := auth.NewJWKClient(auth.JWKClientOptions{URI: "https://thailekha.auth0.com/.well-known/jwks.json"})
-	audience := "https://rooms-checker-go.herokuapp.com/"
-	configuration := auth.NewConfiguration(client, []string{audience}, "https://thailekha.auth0.com/", jose.RS256)
-	return auth.NewValidator(configuration)
+	render.Render(w, r, NewHistoryResponse(fft.QueryHistory(filter)))
 }
 
-func getCors() *cors.Cors {
-	return cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:           300, // Maximum value not ignored by any of major browsers
+// POST /api/private/me/history/{id}/rerun
+func rerunMySearch(w http.ResponseWriter, r *http.Request) {
+	entry, ok := myHistoryEntry(r)
+	if !ok {
+		render.Render(w, r, ErrNotFound(errNoSuchSearch))
+		return
+	}
+
+	search(w, r, &FreeTimesRequest{
+		Week:      entry.Week,
+		Weekday:   entry.Weekday,
+		StartTime: entry.StartTime,
+		EndTime:   entry.EndTime,
+		Rooms:     entry.Rooms,
 	})
 }
 
-/**
- * Input: JWTValidator, Output a func that receives a http.Handler and returns a http.Handler
- */
-func validateJwtToken(validator *auth.JWTValidator) func(next http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		fn := func(w http.ResponseWriter, r *http.Request) {
-			_, err := validator.ValidateRequest(r)
+// DELETE /api/private/me/history/{id}
+func deleteMySearch(w http.ResponseWriter, r *http.Request) {
+	entry, ok := myHistoryEntry(r)
+	if !ok || !fft.DeleteHistoryEntry(entry.ID) {
+		render.Render(w, r, ErrNotFound(errNoSuchSearch))
+		return
+	}
 
-			if err != nil {
-				render.Render(w, r, ErrUnauthorizedRequest(err))
-				return
-			}
+	render.NoContent(w, r)
+}
 
-			next.ServeHTTP(w, r)
-		}
-		return http.HandlerFunc(fn)
+// GET /api/private/me/feeds
+func getMyFeeds(w http.ResponseWriter, r *http.Request) {
+	user := userFromContext(r)
+	if user == "" {
+		render.Render(w, r, ErrUnauthorizedRequest(errNoSubject))
+		return
 	}
-}
 
-func validateJwtTokenAndScope(validator *auth.JWTValidator) func(next http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		fn := func(w http.ResponseWriter, r *http.Request) {
-			token, err := validator.ValidateRequest(r)
+	render.Render(w, r, NewFeedsResponse(r, fft.UserFeeds(user)))
+}
 
-			if err != nil {
-				render.Render(w, r, ErrUnauthorizedRequest(err))
-				return
-			}
+// POST /api/private/me/feeds
+func createMyFeed(w http.ResponseWriter, r *http.Request) {
+	user := userFromContext(r)
+	if user == "" {
+		render.Render(w, r, ErrUnauthorizedRequest(errNoSubject))
+		return
+	}
 
-			if !hasSufficientScope(r, validator, token) {
-				render.Render(w, r, ErrInsufficientScopeRequest(e.New("You do not have the read:history scope.")))
-				return
-			}
+	data := &FeedRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
 
-			next.ServeHTTP(w, r)
-		}
-		return http.HandlerFunc(fn)
+	feed, err := fft.IssueFeed(user, data.Rooms)
+	if err != nil {
+		render.Render(w, r, ErrFFT(err))
+		return
 	}
+
+	render.Status(r, 201)
+	render.Render(w, r, NewFeedResponse(r, feed))
 }
 
-// https://auth0.com/docs/quickstart/backend/golang/01-authorization
-func hasSufficientScope(r *http.Request, validator *auth.JWTValidator, token *jwt.JSONWebToken) bool {
-	claims := map[string]interface{}{}
-	err := validator.Claims(r, token, &claims)
+// DELETE /api/private/me/feeds/{id}
+func revokeMyFeed(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	user := userFromContext(r)
+	if err != nil || user == "" {
+		render.Render(w, r, ErrNotFound(errNoSuchFeed))
+		return
+	}
 
+	revoked, err := fft.RevokeFeed(user, id)
 	if err != nil {
-		fmt.Println(err)
+		render.Render(w, r, ErrFFT(err))
+		return
+	}
+	if !revoked {
+		render.Render(w, r, ErrNotFound(errNoSuchFeed))
+		return
 	}
 
-	return err == nil && strings.Contains(claims["scope"].(string), "read:history")
+	render.NoContent(w, r)
 }
 
-//==============================
-// middleware configs (end)
-//==============================
+// POST /api/private/jobs
+// Starts a free times search in the background, for searches of so many
+// rooms that they would outlast the router's timeout. Poll the job for its
+// progress and result.
+func createJob(w http.ResponseWriter, r *http.Request) {
+	user := userFromContext(r)
+	if user == "" {
+		render.Render(w, r, ErrUnauthorizedRequest(errNoSubject))
+		return
+	}
 
-//============================
-// Request related (start)
-//============================
+	data := &FreeTimesRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
 
-type FreeTimesRequest struct {
-	Weekday   string
-	StartTime string
-	EndTime   string
-	Rooms     []string
-}
+	// progress counts each room once, found, unavailable or unknown; binding
+	// left each in data.Rooms once
+	job, err := fft.StartJob(user, len(data.Rooms), func(ctx context.Context, progress func()) (interface{}, error) {
+		result, fftErr := runSearchAs(ctx, user, data, nil, func(string) { progress() })
+		if fftErr != nil {
+			return nil, fftErr
+		}
+		return freeTimesResponse(data, result), nil
+	})
+	if err != nil {
+		render.Render(w, r, ErrFFT(err))
+		return
+	}
 
-func (f *FreeTimesRequest) Bind(r *http.Request) error {
-	return nil
+	w.Header().Set("Location", strings.TrimSuffix(r.URL.Path, "/")+"/"+strconv.FormatInt(job.ID, 10))
+	response := NewJobResponse(job)
+	response.Notice = jobNotice
+	renderJob(w, r, http.StatusAccepted, response)
 }
 
-func ErrInvalidRequest(err error) render.Renderer {
-	return &ErrResponse{
-		Err:            err,
-		HTTPStatusCode: 400,
-		StatusText:     "Invalid request.",
-		ErrorText:      err.Error(),
+// GET /api/private/jobs/{id}
+func getJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		render.Render(w, r, ErrNotFound(errNoSuchJob))
+		return
 	}
-}
 
-func ErrUnauthorizedRequest(err error) render.Renderer {
-	return &ErrResponse{
-		Err:            err,
-		HTTPStatusCode: 403,
-		StatusText:     "Unauthorized request.",
-		ErrorText:      err.Error(),
+	job, ok := fft.GetJob(userFromContext(r), id)
+	if !ok {
+		render.Render(w, r, ErrNotFound(errNoSuchJob))
+		return
 	}
+
+	renderJob(w, r, http.StatusOK, NewJobResponse(job))
 }
 
-func ErrInsufficientScopeRequest(err error) render.Renderer {
-	return &ErrResponse{
-		Err:            err,
-		HTTPStatusCode: 401,
-		StatusText:     "Insufficient scope",
-		ErrorText:      err.Error(),
+// jobNotice tells whoever starts a job that it is not stored durably.
+const jobNotice = "Jobs are kept in memory: a job is lost, running or done, when the server restarts"
+
+// renderJob answers with a job and status, even when the job failed.
+// render.Render would render the job's error too, and so let it set the
+// status. Running jobs ask to be polled again after a second.
+func renderJob(w http.ResponseWriter, r *http.Request, status int, job *JobResponse) {
+	if job.Status == fft.JobRunning {
+		w.Header().Set("Retry-After", "1")
 	}
+	render.Status(r, status)
+	render.Respond(w, r, job)
 }
 
-//============================
-// Request related (end)
-//============================
+// DELETE /api/private/jobs/{id}
+// Cancels the job if it is still running, and forgets it either way.
+func cancelJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	user := userFromContext(r)
 
-//============================
-// Response related (start)
-//============================
+	if err != nil || user == "" || !fft.CancelJob(user, id) {
+		render.Render(w, r, ErrNotFound(errNoSuchJob))
+		return
+	}
 
-type FreeTimesResponse struct {
-	Rooms []fft.RoomTimes `json:"rooms"`
+	render.NoContent(w, r)
 }
 
-type AllRoomsResponse struct {
-	Rooms []string `json:"rooms"`
-}
+// GET /api/public/feeds/{token}.ics
+// The token authenticates the request, as calendar apps cannot send a JWT.
+func getFeed(w http.ResponseWriter, r *http.Request) {
+	feed, ok := fft.GetFeed(strings.TrimSuffix(chi.URLParam(r, "token"), ".ics"))
+	if !ok {
+		render.Render(w, r, ErrNotFound(errNoSuchFeed))
+		return
+	}
 
-type HistoryResponse struct {
-	History string `json:"history"`
-}
+	weeks := feedWeeks(time.Now())
+	events, freshness, err := fft.FeedEvents(feed.Rooms, weeks)
+	if err != nil {
+		// failed fetches are not tried again for FailureTTL
+		w.Header().Set("Retry-After", strconv.Itoa(int(fft.FailureTTL.Seconds())))
+		render.Render(w, r, ErrUnavailable(err))
+		return
+	}
 
-func NewFreeTimesResponse(roomTimes []fft.RoomTimes) *FreeTimesResponse {
-	return &FreeTimesResponse{roomTimes}
-}
+	// the feed moves on a week every Monday, whether or not the timetables
+	// of the new week changed lately
+	if start := fft.WeekStart(weeks[0]); start.After(freshness.Modified) {
+		freshness.Modified = start
+	}
+	if notModified(w, r, fmt.Sprint(weeks), freshness) {
+		return
+	}
 
-func NewAllRoomsResponse(rooms []string) *AllRoomsResponse {
-	return &AllRoomsResponse{rooms}
+	writeCalendar(w, "rooms.ics", "Free rooms: "+strings.Join(feed.Rooms, ", "), events)
 }
 
-func NewHistoryResponse(history string) *HistoryResponse {
-	return &HistoryResponse{history}
-}
+// GET /api/analytics/usage?from=&to=&format=csv
+func getUsage(w http.ResponseWriter, r *http.Request) {
+	to := time.Now()
+	from := to.Add(-defaultUsagePeriod)
 
-func (ft *FreeTimesResponse) Render(w http.ResponseWriter, r *http.Request) error {
-	return nil
+	var err error
+	if from, err = timeParam(r, "from", from); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	if to, err = timeParam(r, "to", to); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	render.Render(w, r, NewUsageResponse(fft.Analyze(from, to)))
 }
 
-func (al *AllRoomsResponse) Render(w http.ResponseWriter, r *http.Request) error {
-	return nil
+// POST /api/private/freetimes
+func checkFreeTimes(w http.ResponseWriter, r *http.Request) {
+	data := &FreeTimesRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	search(w, r, data)
 }
 
-func (h *HistoryResponse) Render(w http.ResponseWriter, r *http.Request) error {
-	return nil
+// GET /api/private/freetimes?day=&week=&from=&to=&rooms=&type=
+// Redirects to the canonical query of the search first, so that equivalent
+// searches share one URL and so one entry in HTTP caches. The redirect is a
+// 302, so browsers do not keep it for good should the canonical form change.
+func getFreeTimes(w http.ResponseWriter, r *http.Request) {
+	data, err := NewFreeTimesQuery(r.URL.Query())
+	if err == nil {
+		err = data.Bind(r)
+	}
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	canonical := data.Query()
+	if format := r.URL.Query().Get("format"); format != "" {
+		canonical.Set("format", format)
+	}
+	if query := canonical.Encode(); query != r.URL.RawQuery {
+		http.Redirect(w, r, r.URL.Path+"?"+query, http.StatusFound)
+		return
+	}
+
+	// the answer needs a token, so only the user's own cache may keep it,
+	// checking back with If-None-Match before reusing it
+	w.Header().Set("Cache-Control", "private, no-cache")
+	search(w, r, data)
 }
 
-type ErrResponse struct {
-	Err            error `json:"-"` // low-level runtime error
-	HTTPStatusCode int   `json:"-"` // http response status code
+// POST /api/private/freetimes/stream
+func streamFreeTimes(w http.ResponseWriter, r *http.Request) {
+	data := &FreeTimesRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	stream, ok := newEventStream(w, r)
+	if !ok {
+		render.Render(w, r, ErrFFT(errNoStreaming))
+		return
+	}
+
+	// the search gives up once the client disconnects
+	result, fftErr := runSearchAs(r.Context(), userFromContext(r), data, func(roomTimes fft.RoomTimes) {
+		stream.send("room", roomTimes)
+	}, nil)
+
+	if r.Context().Err() != nil {
+		return
+	}
 
-	StatusText string `json:"status"`          // user-level status message
-	AppCode    int64  `json:"code,omitempty"`  // application-specific error code
-	ErrorText  string `json:"error,omitempty"` // application-level error message, for debugging
+	if fftErr != nil && !stream.started {
+		renderSearchError(w, r, fftErr)
+		return
+	}
+
+	if fftErr != nil {
+		stream.send("error", ErrFFT(fftErr))
+		return
+	}
+
+	stream.send("summary", freeTimesResponse(data, result))
 }
 
-func (e *ErrResponse) Render(w http.ResponseWriter, r *http.Request) error {
-	render.Status(r, e.HTTPStatusCode)
-	return nil
+// POST /api/private/freetimes/batch
+// Runs the searches at once, so those over the same rooms share their fetches
+// through the timetable cache, and answers each in turn, failed or not.
+func checkFreeTimesBatch(w http.ResponseWriter, r *http.Request) {
+	data := BatchRequest{}
+	if err := render.Bind(r, &data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	items := make([]*BatchItemResponse, len(data))
+	var wg sync.WaitGroup
+
+	for i, raw := range data {
+		wg.Add(1)
+		go func(i int, raw json.RawMessage) {
+			defer wg.Done()
+			items[i] = batchItem(r, raw)
+		}(i, raw)
+	}
+
+	wg.Wait()
+
+	// the batch succeeds however many of its searches failed. render.RenderList
+	// would render the errors of the items, and so let the last failed one
+	// set the status of the batch.
+	render.Status(r, http.StatusOK)
+	render.Respond(w, r, items)
 }
 
-func ErrFFT(err error) render.Renderer {
-	return &ErrResponse{
-		Err:            err,
-		HTTPStatusCode: 422,
-		StatusText:     "Error finding free times",
-		ErrorText:      err.Error(),
+// batchItem decodes, checks and runs one search of a batch, so that a
+// search that cannot be read fails alone.
+func batchItem(r *http.Request, raw json.RawMessage) *BatchItemResponse {
+	if !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		return NewBatchItemError(ErrInvalidRequest(errSearchNotObject))
+	}
+
+	query := &FreeTimesRequest{}
+	if err := decodeJSONStrict(bytes.NewReader(raw), query); err != nil {
+		return NewBatchItemError(ErrInvalidRequest(err))
+	}
+	if err := query.Bind(r); err != nil {
+		return NewBatchItemError(ErrInvalidRequest(err))
+	}
+
+	result, fftErr := runSearch(r, query, nil)
+	if fftErr != nil {
+		return NewBatchItemError(ErrFFT(fftErr))
 	}
+
+	return &BatchItemResponse{Status: http.StatusOK, Result: freeTimesResponse(query, result)}
 }
 
-//============================
-// Response related (end)
-//============================
+// search runs a free times search and renders the result. It is only
+// recorded in the history when the result is sent, not when a cache
+// revalidating it gets a 304.
+func search(w http.ResponseWriter, r *http.Request, data *FreeTimesRequest) {
+	start := time.Now()
+	result, fftErr := fft.FindContext(context.Background(), data.Week, data.Weekday, data.StartTime, data.EndTime, data.Rooms, nil)
 
-//============================
-// Helpers (start)
-//============================
+	if fftErr != nil {
+		renderSearchError(w, r, fftErr)
+		return
+	}
+
+	if r.Method == "GET" && notModified(w, r, responseFormat(r), result.Freshness) {
+		return
+	}
+
+	recordSearch(userFromContext(r), data, result, start)
+
+	if wantsCalendar(r) {
+		blocks := fft.Blocks(result, data.StartTime, data.EndTime, data.MinDuration)
+		writeCalendar(w, "freetimes-"+data.Weekday+".ics", "Free rooms on "+data.Weekday, fft.FreeEvents(blocks, data.Weekday, data.Week))
+		return
+	}
+
+	render.Render(w, r, freeTimesResponse(data, result))
+}
+
+// runSearch runs a free times search, handing each room to found as it
+// comes, and records it in the history.
+func runSearch(r *http.Request, data *FreeTimesRequest, found func(fft.RoomTimes)) (*fft.Result, error) {
+	return runSearchAs(context.Background(), userFromContext(r), data, found, nil)
+}
+
+// runSearchAs is runSearch on behalf of user, giving up once ctx is done and
+// telling searched of each room it is done with, see fft.FindProgress.
+func runSearchAs(ctx context.Context, user string, data *FreeTimesRequest, found func(fft.RoomTimes), searched func(string)) (*fft.Result, error) {
+	start := time.Now()
+	result, fftErr := fft.FindProgress(ctx, data.Week, data.Weekday, data.StartTime, data.EndTime, data.Rooms, found, searched)
+
+	if fftErr == nil {
+		recordSearch(user, data, result, start)
+	}
+
+	return result, fftErr
+}
+
+// recordSearch records a search started at start in the history of user.
+func recordSearch(user string, data *FreeTimesRequest, result *fft.Result, start time.Time) {
+	fft.RecordSearch(fft.HistoryEntry{
+		Time:      start,
+		User:      user,
+		Weekday:   data.Weekday,
+		Week:      data.Week,
+		StartTime: data.StartTime,
+		EndTime:   data.EndTime,
+		Rooms:     data.Rooms,
+		Results:   len(result.Rooms),
+		Latency:   int64(time.Since(start) / time.Millisecond),
+	})
+}
+
+func renderSearchError(w http.ResponseWriter, r *http.Request, fftErr error) {
+	if fftErr == fft.ErrUpstreamUnavailable {
+		render.Render(w, r, ErrUnavailable(fftErr))
+		return
+	}
+
+	render.Render(w, r, ErrFFT(fftErr))
+}
+
+// freeTimesResponse builds the response to a search, with whichever extras
+// the request asked for.
+func freeTimesResponse(data *FreeTimesRequest, result *fft.Result) *FreeTimesResponse {
+	response := NewFreeTimesResponse(result)
+
+	if data.MinDuration > 0 {
+		response.Blocks = fft.Blocks(result, data.StartTime, data.EndTime, data.MinDuration)
+		response.Rooms = fft.WithinBlocks(result.Rooms, response.Blocks)
+	}
+
+	if minRooms := data.minRooms(result); minRooms > 0 {
+		response.Common = fft.Common(result, data.StartTime, data.EndTime, minRooms)
+	}
+
+	response.Suggestions = fft.Suggest(result, data.Weekday, data.StartTime, data.EndTime)
+
+	if data.Explain {
+		response.Explanations = fft.Explain(result, data.Weekday, data.StartTime, data.EndTime, data.Rooms)
+	}
+
+	return response
+}
+
+// myHistoryEntry looks up the {id} search, provided the caller made it.
+func myHistoryEntry(r *http.Request) (fft.HistoryEntry, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return fft.HistoryEntry{}, false
+	}
+
+	entry, ok := fft.GetHistoryEntry(id)
+	user := userFromContext(r)
+
+	return entry, ok && user != "" && entry.User == user
+}
+
+// POST /api/private/matrix
+func checkMatrix(w http.ResponseWriter, r *http.Request) {
+	data := &MatrixRequest{}
+	if err := render.Bind(r, data); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	matrix, fftErr := fft.FindMatrix(data.Week, data.Days, data.StartTime, data.EndTime, data.Rooms)
+
+	if fftErr == fft.ErrUpstreamUnavailable {
+		render.Render(w, r, ErrUnavailable(fftErr))
+		return
+	}
+
+	if fftErr != nil {
+		render.Render(w, r, ErrFFT(fftErr))
+		return
+	}
+
+	render.Render(w, r, NewMatrixResponse(matrix))
+}
+
+//==============================
+// endpoints (end)
+//==============================
+
+//==============================
+// middleware configs (start)
+//==============================
+
+func getValidator() *auth.JWTValidator {
+	client := auth.NewJWKClient(auth.JWKClientOptions{URI: "https://thailekha.auth0.com/.well-known/jwks.json"})
+	audience := "https://rooms-checker-go.herokuapp.com/"
+	configuration := auth.NewConfiguration(client, []string{audience}, "https://thailekha.auth0.com/", jose.RS256)
+	return auth.NewValidator(configuration)
+}
+
+func getCors() *cors.Cors {
+	return cors.New(cors.Options{
+		AllowedOrigins:   []string{"*"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "If-None-Match", "If-Modified-Since"},
+		ExposedHeaders:   []string{"Link", "WWW-Authenticate", "ETag", "Last-Modified", "Location", "Retry-After"},
+		AllowCredentials: true,
+		MaxAge:           300, // Maximum value not ignored by any of major browsers
+	})
+}
+
+/**
+ * Input: JWTValidator, Output a func that receives a http.Handler and returns a http.Handler
+ */
+func validateJwtToken(validator *auth.JWTValidator) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			token, err := validator.ValidateRequest(r)
+
+			if err != nil {
+				render.Render(w, r, ErrUnauthorizedRequest(err))
+				return
+			}
+
+			next.ServeHTTP(w, withUser(r, validator, token))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+func validateJwtTokenAndScope(validator *auth.JWTValidator, scope string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			token, err := validator.ValidateRequest(r)
+
+			if err != nil {
+				render.Render(w, r, ErrUnauthorizedRequest(err))
+				return
+			}
+
+			if !hasSufficientScope(r, validator, token, scope) {
+				render.Render(w, r, ErrInsufficientScopeRequest(scope))
+				return
+			}
+
+			next.ServeHTTP(w, withUser(r, validator, token))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// optionalJwtToken lets requests without a token through anonymously and
+// validates the token of the others.
+func optionalJwtToken(validator *auth.JWTValidator) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, err := validator.ValidateRequest(r)
+
+			if err != nil {
+				render.Render(w, r, ErrUnauthorizedRequest(err))
+				return
+			}
+
+			next.ServeHTTP(w, withUser(r, validator, token))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// https://auth0.com/docs/quickstart/backend/golang/01-authorization
+func hasSufficientScope(r *http.Request, validator *auth.JWTValidator, token *jwt.JSONWebToken, scope string) bool {
+	claims := map[string]interface{}{}
+	err := validator.Claims(r, token, &claims)
+
+	if err != nil {
+		log.Printf("reading the claims of a token: %v", err)
+		return false
+	}
+
+	scopes, _ := claims["scope"].(string)
+	for _, s := range strings.Fields(scopes) {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type contextKey struct {
+	name string
+}
+
+var userCtxKey = &contextKey{"User"}
+
+// withUser stores the subject of a validated token in the request context.
+func withUser(r *http.Request, validator *auth.JWTValidator, token *jwt.JSONWebToken) *http.Request {
+	claims := jwt.Claims{}
+	if err := validator.Claims(r, token, &claims); err != nil {
+		log.Printf("reading the claims of a token: %v", err)
+	}
+
+	return r.WithContext(context.WithValue(r.Context(), userCtxKey, claims.Subject))
+}
+
+func userFromContext(r *http.Request) string {
+	user, _ := r.Context().Value(userCtxKey).(string)
+	return user
+}
+
+//==============================
+// middleware configs (end)
+//==============================
+
+//============================
+// Request related (start)
+//============================
+
+type FreeTimesRequest struct {
+	Week        int // defaults to fft.DefaultWeek
+	Weekday     string
+	StartTime   string
+	EndTime     string
+	Rooms       []string
+	MinDuration int    // minutes, when set only contiguous free blocks this long are returned
+	Mode        string // "any" (default), "all" or "atleast" rooms free at the same time
+	MinRooms    int    // how many rooms must be free at once in "atleast" mode
+	Explain     bool   // tell for each room and slot whether it is free or what it is booked for
+}
+
+const (
+	modeAny     = "any"
+	modeAll     = "all"
+	modeAtLeast = "atleast"
+)
+
+// minRooms is how many rooms must be free at the same time for a slot to be
+// common, 0 when the request does not look for common slots. In "all" mode
+// that is every distinct room asked for, so that a room whose timetable
+// could not be read leaves no slot common.
+func (f *FreeTimesRequest) minRooms(result *fft.Result) int {
+	switch f.Mode {
+	case modeAll:
+		return len(result.Asked())
+	case modeAtLeast:
+		return f.MinRooms
+	default:
+		return 0
+	}
+}
+
+func (f *FreeTimesRequest) Bind(r *http.Request) error {
+	v := &ValidationError{}
+
+	if f.Week == 0 {
+		f.Week = fft.DefaultWeek
+	}
+
+	if f.Week < fft.FirstWeek || f.Week > fft.LastWeek {
+		v.add("week", "must be between "+strconv.Itoa(fft.FirstWeek)+" and "+strconv.Itoa(fft.LastWeek))
+	}
+
+	if !fft.IsWeekday(f.Weekday) {
+		v.add("weekday", "must be one of "+strings.Join(fft.GetWeekdays(), ", "))
+	}
+
+	validateWindow(v, f.StartTime, f.EndTime)
+
+	// a room asked for twice is searched once, and counts once towards minRooms
+	f.Rooms = distinct(f.Rooms)
+
+	// explaining tells which rooms are not teaching rooms instead
+	if !f.Explain {
+		validateRooms(v, f.Rooms)
+	}
+
+	if len(f.Rooms) == 0 {
+		v.add("rooms", "must list at least one room")
+	}
+
+	if f.MinDuration < 0 {
+		v.add("minDuration", "must not be negative")
+	}
+
+	switch f.Mode {
+	case "", modeAny, modeAll:
+	case modeAtLeast:
+		if f.MinRooms < 1 || f.MinRooms > len(f.Rooms) {
+			v.add("minRooms", "must be between 1 and the number of rooms")
+		}
+	default:
+		v.add("mode", "must be one of "+strings.Join([]string{modeAny, modeAll, modeAtLeast}, ", "))
+	}
+
+	return v.orNil()
+}
+
+// distinct is values without repeats, in the order they first appear.
+func distinct(values []string) []string {
+	var seen []string
+	for _, v := range values {
+		if !fft.Contains(v, seen) {
+			seen = append(seen, v)
+		}
+	}
+	return seen
+}
+
+// freeTimesQueryParams are the query parameters of GET /api/private/freetimes.
+var freeTimesQueryParams = []string{"day", "week", "from", "to", "rooms", "type", "minRooms", "minDuration", "explain", "format"}
+
+// NewFreeTimesQuery reads a search from query parameters. Rooms may be
+// repeated or comma separated, and are uppercased, sorted and deduplicated
+// like the day is lowercased, so equivalent queries make equal requests.
+func NewFreeTimesQuery(q url.Values) (*FreeTimesRequest, error) {
+	v := &ValidationError{}
+	f := &FreeTimesRequest{
+		Weekday:   strings.ToLower(q.Get("day")),
+		StartTime: q.Get("from"),
+		EndTime:   q.Get("to"),
+		Mode:      strings.ToLower(q.Get("type")),
+	}
+
+	names := make([]string, 0, len(q))
+	for name := range q {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !fft.Contains(name, freeTimesQueryParams) {
+			v.add(name, "is not a known parameter")
+		}
+	}
+
+	intParam := func(name string, n *int) {
+		if value := q.Get(name); value != "" {
+			var err error
+			if *n, err = strconv.Atoi(value); err != nil {
+				v.add(name, "must be a number")
+			}
+		}
+	}
+	intParam("week", &f.Week)
+	intParam("minRooms", &f.MinRooms)
+	intParam("minDuration", &f.MinDuration)
+
+	if value := q.Get("explain"); value != "" {
+		var err error
+		if f.Explain, err = strconv.ParseBool(value); err != nil {
+			v.add("explain", "must be a boolean")
+		}
+	}
+
+	for _, value := range q["rooms"] {
+		for _, room := range strings.Split(value, ",") {
+			if room = strings.ToUpper(strings.TrimSpace(room)); room != "" && !fft.Contains(room, f.Rooms) {
+				f.Rooms = append(f.Rooms, room)
+			}
+		}
+	}
+	sort.Strings(f.Rooms)
+
+	return f, v.orNil()
+}
+
+// Query is the canonical query of a search: parameters in alphabetical
+// order, each room once and in order, and defaults left out.
+func (f *FreeTimesRequest) Query() url.Values {
+	q := url.Values{}
+
+	set := func(name string, value string, def string) {
+		if value != def {
+			q.Set(name, value)
+		}
+	}
+	set("day", f.Weekday, "")
+	set("week", strconv.Itoa(f.Week), strconv.Itoa(fft.DefaultWeek))
+	set("from", f.StartTime, "")
+	set("to", f.EndTime, "")
+	if f.Mode != modeAny {
+		set("type", f.Mode, "")
+	}
+	set("minDuration", strconv.Itoa(f.MinDuration), "0")
+	set("explain", strconv.FormatBool(f.Explain), "false")
+	if f.Mode == modeAtLeast {
+		set("minRooms", strconv.Itoa(f.MinRooms), "")
+	}
+	q["rooms"] = f.Rooms
+
+	return q
+}
+
+// maxBatchSize caps the searches of one batch, which all run at once.
+const maxBatchSize = 50
+
+var errSearchNotObject = e.New("Each search of a batch must be an object")
+
+// BatchRequest is a list of searches. Only the list itself is read and
+// checked here, each search is decoded and checked on its own so that it
+// can fail alone.
+type BatchRequest []json.RawMessage
+
+func (b BatchRequest) Bind(r *http.Request) error {
+	v := &ValidationError{}
+
+	if len(b) == 0 || len(b) > maxBatchSize {
+		v.add("batch", "must list between 1 and "+strconv.Itoa(maxBatchSize)+" searches")
+	}
+
+	return v.orNil()
+}
+
+// MatrixRequest leaves out Days for the whole week, StartTime and EndTime
+// for the whole day and Rooms for every room.
+type MatrixRequest struct {
+	Week      int
+	Days      []string
+	StartTime string
+	EndTime   string
+	Rooms     []string
+}
+
+func (m *MatrixRequest) Bind(r *http.Request) error {
+	v := &ValidationError{}
+
+	if m.Week == 0 {
+		m.Week = fft.DefaultWeek
+	}
+
+	if m.Week < fft.FirstWeek || m.Week > fft.LastWeek {
+		v.add("week", "must be between "+strconv.Itoa(fft.FirstWeek)+" and "+strconv.Itoa(fft.LastWeek))
+	}
+
+	for i, weekday := range m.Days {
+		if !fft.IsWeekday(weekday) {
+			v.add("days["+strconv.Itoa(i)+"]", "must be one of "+strings.Join(fft.GetWeekdays(), ", "))
+		}
+	}
+
+	if m.StartTime != "" || m.EndTime != "" {
+		validateWindow(v, m.StartTime, m.EndTime)
+	}
+
+	validateRooms(v, m.Rooms)
+
+	return v.orNil()
+}
+
+type FeedRequest struct {
+	Rooms []string
+}
+
+func (f *FeedRequest) Bind(r *http.Request) error {
+	v := &ValidationError{}
+
+	if len(f.Rooms) == 0 {
+		v.add("rooms", "must not be empty")
+	}
+
+	validateRooms(v, f.Rooms)
+
+	return v.orNil()
+}
+
+// FieldError tells why a field of a request body was rejected.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (v *ValidationError) Error() string {
+	reasons := make([]string, 0, len(v.Fields))
+	for _, f := range v.Fields {
+		reasons = append(reasons, f.Field+" "+f.Reason)
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func (v *ValidationError) add(field string, reason string) {
+	v.Fields = append(v.Fields, FieldError{field, reason})
+}
+
+func (v *ValidationError) orNil() error {
+	if len(v.Fields) == 0 {
+		return nil
+	}
+	return v
+}
+
+func validateWindow(v *ValidationError, startTime string, endTime string) {
+	supported := "must be one of " + strings.Join(fft.GetSupportedTimes(), ", ")
+
+	if !fft.IsSupportedTime(startTime) {
+		v.add("startTime", supported)
+	}
+
+	if !fft.IsSupportedTime(endTime) {
+		v.add("endTime", supported)
+	} else if fft.IsSupportedTime(startTime) && !fft.InOrder(startTime, endTime) {
+		v.add("endTime", "must not be before startTime")
+	}
+}
+
+func validateRooms(v *ValidationError, rooms []string) {
+	for i, room := range rooms {
+		if !fft.IsRoom(room) {
+			v.add("rooms["+strconv.Itoa(i)+"]", "is not a known room: "+room)
+		}
+	}
+}
+
+// decodeStrict decodes JSON bodies like render.DefaultDecoder does, but
+// rejects fields the payload does not have.
+func decodeStrict(r *http.Request, v interface{}) error {
+	if render.GetRequestContentType(r) != render.ContentTypeJSON {
+		return render.DefaultDecoder(r, v)
+	}
+
+	defer io.Copy(ioutil.Discard, r.Body)
+
+	return decodeJSONStrict(r.Body, v)
+}
+
+// decodeJSONStrict decodes a JSON body into v, telling which field was
+// unknown or of the wrong type.
+func decodeJSONStrict(body io.Reader, v interface{}) error {
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(v)
+
+	if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+		return &ValidationError{[]FieldError{{typeErr.Field, "must be " + jsonKind(typeErr.Type)}}}
+	}
+
+	if err != nil && strings.HasPrefix(err.Error(), "json: unknown field ") {
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return &ValidationError{[]FieldError{{field, "is not a known field"}}}
+	}
+
+	return err
+}
+
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int64, reflect.Float64:
+		return "a number"
+	default:
+		return "an object"
+	}
+}
+
+func ErrInvalidRequest(err error) render.Renderer {
+	response := newErrResponse(err, kindInvalidRequest)
+
+	if v, ok := err.(*ValidationError); ok {
+		response.Fields = v.Fields
+	}
+
+	return response
+}
+
+func ErrUnauthorizedRequest(err error) render.Renderer {
+	response := newErrResponse(err, kindUnauthorized)
+	response.authenticate = bearerChallenge(err, "")
+	return response
+}
+
+func ErrInsufficientScopeRequest(scope string) render.Renderer {
+	response := newErrResponse(e.New("You do not have the "+scope+" scope."), kindInsufficientScope)
+	response.authenticate = bearerChallenge(nil, scope)
+	return response
+}
+
+//============================
+// Request related (end)
+//============================
+
+//============================
+// Response related (start)
+//============================
+
+type FreeTimesResponse struct {
+	Rooms        []fft.RoomTimes   `json:"rooms"`                  // only the times within Blocks when MinDuration is set
+	Unavailable  []string          `json:"unavailable,omitempty"`  // rooms never fetched successfully
+	Stale        bool              `json:"stale"`                  // answered from a snapshot older than the cache TTL
+	Age          int64             `json:"age"`                    // age in seconds of the oldest snapshot used
+	Blocks       []fft.Block       `json:"blocks,omitempty"`       // free blocks of at least MinDuration, best fit first
+	Common       []fft.CommonSlot  `json:"common,omitempty"`       // times enough rooms are free at once in "all" and "atleast" modes
+	Explanations []fft.Explanation `json:"explanations,omitempty"` // why each room is in the results or not, when asked to explain
+	Suggestions  []fft.Suggestion  `json:"suggestions,omitempty"`  // near misses, when no room is free for the whole window
+
+	times   []string // columns of the table
+	asked   []string // rows of the table
+	unknown []string
+}
+
+// BatchItemResponse is the answer to one search of a batch, with the status
+// it would have had on its own.
+type BatchItemResponse struct {
+	Status int                `json:"status"`
+	Result *FreeTimesResponse `json:"result,omitempty"`
+	Error  *ErrResponse       `json:"error,omitempty"`
+}
+
+// JobResponse is a job with its result once it is done, or its error once
+// it failed.
+type JobResponse struct {
+	fft.Job
+	Result *FreeTimesResponse `json:"result,omitempty"`
+	Error  *ErrResponse       `json:"error,omitempty"`
+	Notice string             `json:"notice,omitempty"` // only when the job is started
+}
+
+type AllRoomsResponse struct {
+	Rooms []string `json:"rooms"`
+}
+
+type HistoryResponse struct {
+	History    []fft.HistoryEntry `json:"history"`
+	NextCursor string             `json:"nextCursor,omitempty"` // pass as ?cursor= for the next page
+}
+
+type UsageResponse struct {
+	fft.Usage
+}
+
+type TimetableResponse struct {
+	Room      string        `json:"room"`
+	Week      int           `json:"week"`
+	WeekStart string        `json:"weekStart"` // date of the Monday
+	Days      []DayResponse `json:"days"`
+	Stale     bool          `json:"stale"`
+	Age       int64         `json:"age"`
+}
+
+type FreeNowResponse struct {
+	Time        string         `json:"time"` // the moment asked about, on the campus clock
+	Week        int            `json:"week"`
+	Weekday     string         `json:"weekday"`
+	Slot        string         `json:"slot,omitempty"` // empty outside teaching hours
+	Rooms       []fft.FreeRoom `json:"rooms"`
+	Unavailable []string       `json:"unavailable,omitempty"`
+	Stale       bool           `json:"stale"`
+	Age         int64          `json:"age"`
+}
+
+type MatrixResponse struct {
+	Week        int              `json:"week"`
+	Days        []string         `json:"days"`
+	Times       []string         `json:"times"`
+	Rooms       []fft.RoomMatrix `json:"rooms"`
+	Unavailable []string         `json:"unavailable,omitempty"`
+	Stale       bool             `json:"stale"`
+	Age         int64            `json:"age"`
+}
+
+type FeedResponse struct {
+	fft.Feed
+	URL string `json:"url"` // webcal:// address to subscribe to
+}
+
+type FeedsResponse struct {
+	Feeds []*FeedResponse `json:"feeds"`
+}
+
+type DayResponse struct {
+	Weekday string     `json:"weekday"`
+	Slots   []fft.Slot `json:"slots"`
+}
+
+func NewFreeTimesResponse(result *fft.Result) *FreeTimesResponse {
+	return &FreeTimesResponse{
+		Rooms:       result.Rooms,
+		Unavailable: result.Unavailable,
+		Stale:       result.Stale,
+		Age:         int64(result.Age.Seconds()),
+		times:       result.Times,
+		asked:       result.Asked(),
+		unknown:     result.Unknown,
+	}
+}
+
+func NewBatchItemError(err render.Renderer) *BatchItemResponse {
+	errResponse := err.(*ErrResponse)
+	return &BatchItemResponse{Status: errResponse.HTTPStatusCode, Error: errResponse}
+}
+
+func NewJobResponse(job fft.Job) *JobResponse {
+	response := &JobResponse{Job: job}
+	if result, ok := job.Result.(*FreeTimesResponse); ok {
+		response.Result = result
+	}
+	if job.Err != nil {
+		response.Error = ErrFFT(job.Err).(*ErrResponse)
+	}
+	return response
+}
+
+func NewAllRoomsResponse(rooms []string) *AllRoomsResponse {
+	return &AllRoomsResponse{rooms}
+}
+
+func NewHistoryResponse(history []fft.HistoryEntry, next int64) *HistoryResponse {
+	response := &HistoryResponse{History: history}
+	if next > 0 {
+		response.NextCursor = strconv.FormatInt(next, 10)
+	}
+	return response
+}
+
+func NewFeedResponse(r *http.Request, feed fft.Feed) *FeedResponse {
+	return &FeedResponse{feed, "webcal://" + r.Host + "/api/public/feeds/" + feed.Token + ".ics"}
+}
+
+func NewFeedsResponse(r *http.Request, feeds []fft.Feed) *FeedsResponse {
+	response := &FeedsResponse{Feeds: make([]*FeedResponse, 0, len(feeds))}
+	for _, feed := range feeds {
+		response.Feeds = append(response.Feeds, NewFeedResponse(r, feed))
+	}
+	return response
+}
+
+func NewUsageResponse(usage fft.Usage) *UsageResponse {
+	return &UsageResponse{usage}
+}
+
+func NewFreeNowResponse(now time.Time, result *fft.NowResult) *FreeNowResponse {
+	return &FreeNowResponse{
+		Time:        now.In(fft.Campus).Format(time.RFC3339),
+		Week:        result.Week,
+		Weekday:     result.Weekday,
+		Slot:        result.Slot,
+		Rooms:       result.Rooms,
+		Unavailable: result.Unavailable,
+		Stale:       result.Stale,
+		Age:         int64(result.Age.Seconds()),
+	}
+}
+
+func NewMatrixResponse(matrix *fft.Matrix) *MatrixResponse {
+	return &MatrixResponse{
+		Week:        matrix.Week,
+		Days:        matrix.Days,
+		Times:       matrix.Times,
+		Rooms:       matrix.Rooms,
+		Unavailable: matrix.Unavailable,
+		Stale:       matrix.Stale,
+		Age:         int64(matrix.Age.Seconds()),
+	}
+}
+
+func NewTimetableResponse(snapshot *fft.Snapshot) *TimetableResponse {
+	tt := snapshot.Timetable
+	days := make([]DayResponse, 0)
+
+	for _, weekday := range fft.GetWeekdays() {
+		days = append(days, DayResponse{weekday, tt.Days[weekday]})
+	}
+
+	return &TimetableResponse{
+		Room:      tt.Room,
+		Week:      tt.Week,
+		WeekStart: fft.WeekStart(tt.Week).Format("2006-01-02"),
+		Days:      days,
+		Stale:     snapshot.Stale(),
+		Age:       int64(snapshot.Age().Seconds()),
+	}
+}
+
+func (ft *FreeTimesResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (al *AllRoomsResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (h *HistoryResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (f *FeedResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (f *FeedsResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// Tabler is a response that can also be sent as CSV or Markdown, the first
+// record being the header.
+type Tabler interface {
+	Table() [][]string
+}
+
+// Table has a row per room searched and a column per time searched. The
+// rows of rooms that are not in the catalog or could not be fetched say so
+// in every column.
+func (ft *FreeTimesResponse) Table() [][]string {
+	records := [][]string{append([]string{"room"}, ft.times...)}
+
+	free := map[string][]string{}
+	for _, roomTimes := range ft.Rooms {
+		free[roomTimes.Room] = roomTimes.Times
+	}
+
+	for _, room := range ft.asked {
+		record := []string{room}
+		for _, t := range ft.times {
+			switch {
+			case fft.Contains(room, ft.unknown):
+				record = append(record, "unknown")
+			case fft.Contains(room, ft.Unavailable):
+				record = append(record, "unavailable")
+			default:
+				record = append(record, freeOrBusy(fft.Contains(t, free[room])))
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// Table has a row per room and a column per day and time. Rooms that could
+// not be fetched have no availability, so their row says so in every column.
+func (m *MatrixResponse) Table() [][]string {
+	header := []string{"room"}
+	for _, day := range m.Days {
+		for _, t := range m.Times {
+			header = append(header, day+" "+t)
+		}
+	}
+
+	records := [][]string{header}
+	for _, room := range m.Rooms {
+		record := []string{room.Room}
+		for _, free := range room.Free {
+			for _, f := range free {
+				record = append(record, freeOrBusy(f))
+			}
+		}
+		for len(record) < len(header) {
+			record = append(record, "unavailable")
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// Table flattens usage into metric,key,searches rows.
+func (u *UsageResponse) Table() [][]string {
+	usage := u.Usage
+	records := [][]string{
+		{"metric", "key", "searches"},
+		{"total", usage.From.Format(time.RFC3339) + "/" + usage.To.Format(time.RFC3339), strconv.Itoa(usage.Searches)},
+	}
+
+	metrics := []struct {
+		name   string
+		counts []fft.Count
+	}{
+		{"room", usage.Rooms},
+		{"weekday", usage.Weekdays},
+		{"timeRange", usage.TimeRanges},
+		{"nothingFound", usage.NothingFound},
+		{"peakHour", usage.PeakHours},
+	}
+
+	for _, metric := range metrics {
+		for _, count := range metric.counts {
+			records = append(records, []string{metric.name, count.Key, strconv.Itoa(count.Searches)})
+		}
+	}
+
+	return records
+}
+
+func (u *UsageResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (fn *FreeNowResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (m *MatrixResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+func (tt *TimetableResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+type ErrResponse struct {
+	Err            error `json:"-"` // low-level runtime error
+	HTTPStatusCode int   `json:"-"` // http response status code
+
+	StatusText string       `json:"status"`           // user-level status message
+	AppCode    int64        `json:"code,omitempty"`   // application-specific error code, see errorCatalog
+	Type       string       `json:"type,omitempty"`   // machine-readable name of the code
+	ErrorText  string       `json:"error,omitempty"`  // application-level error message, for debugging
+	Fields     []FieldError `json:"fields,omitempty"` // request fields that failed validation
+
+	authenticate string // WWW-Authenticate challenge of 401 and 403 responses
+}
+
+func (e *ErrResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	if e.authenticate != "" {
+		w.Header().Set("WWW-Authenticate", e.authenticate)
+	}
+	render.Status(r, e.HTTPStatusCode)
+	return nil
+}
+
+func ErrNotFound(err error) render.Renderer {
+	return newErrResponse(err, kindNotFound)
+}
+
+func ErrFFT(err error) render.Renderer {
+	return newErrResponse(err, kindSearchFailed)
+}
+
+func ErrUnavailable(err error) render.Renderer {
+	return newErrResponse(err, kindUpstreamUnavailable)
+}
+
+//============================
+// Response related (end)
+//============================
+
+//============================
+// Helpers (start)
+//============================
+
+var defaultHistoryLimit = 50
+var maxHistoryLimit = 500
+
+func historyFilter(r *http.Request) (fft.HistoryFilter, error) {
+	q := r.URL.Query()
+	filter := fft.HistoryFilter{
+		User:    q.Get("user"),
+		Room:    q.Get("room"),
+		Weekday: q.Get("weekday"),
+		Limit:   defaultHistoryLimit,
+	}
+
+	var err error
+
+	if filter.From, err = timeParam(r, "from", time.Time{}); err != nil {
+		return filter, err
+	}
+
+	if filter.To, err = timeParam(r, "to", time.Time{}); err != nil {
+		return filter, err
+	}
+
+	if cursor := q.Get("cursor"); cursor != "" {
+		if filter.After, err = strconv.ParseInt(cursor, 10, 64); err != nil || filter.After < 0 {
+			return filter, e.New("Invalid cursor")
+		}
+	}
+
+	if limit := q.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 1 || filter.Limit > maxHistoryLimit {
+			return filter, e.New("limit must be between 1 and " + strconv.Itoa(maxHistoryLimit))
+		}
+	}
+
+	return filter, nil
+}
+
+// weekParam parses ?week=, which defaults to fft.DefaultWeek.
+func weekParam(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("week")
+	if value == "" {
+		return fft.DefaultWeek, nil
+	}
+
+	week, err := strconv.Atoi(value)
+	if err != nil || week < fft.FirstWeek || week > fft.LastWeek {
+		return 0, e.New("week must be between " + strconv.Itoa(fft.FirstWeek) + " and " + strconv.Itoa(fft.LastWeek))
+	}
+
+	return week, nil
+}
+
+// timeParam parses an RFC 3339 query parameter, falling back to def when it
+// is missing.
+func timeParam(r *http.Request, name string, def time.Time) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return t, e.New(name + " must be an RFC 3339 timestamp")
+	}
+
+	return t, nil
+}
+
+var defaultUsagePeriod = 30 * 24 * time.Hour
+
+// tableFormat is "csv" or "markdown" when the client asked for one through
+// ?format= or the Accept header, "" otherwise.
+func tableFormat(r *http.Request) string {
+	format := r.URL.Query().Get("format")
+
+	switch {
+	case format == "csv":
+		return "csv"
+	case format == "markdown" || format == "md":
+		return "markdown"
+	case format != "":
+		return ""
+	}
+
+	switch negotiate(r, "application/json", "text/csv", "text/markdown") {
+	case "text/csv":
+		return "csv"
+	case "text/markdown":
+		return "markdown"
+	}
+	return ""
+}
+
+// respond is render.Respond, sending tables as CSV or Markdown when asked to.
+func respond(w http.ResponseWriter, r *http.Request, v interface{}) {
+	if t, ok := v.(Tabler); ok {
+		switch tableFormat(r) {
+		case "csv":
+			writeCSV(w, path.Base(r.URL.Path)+".csv", t.Table())
+			return
+		case "markdown":
+			writeMarkdown(w, t.Table())
+			return
+		}
+	}
+
+	render.DefaultResponder(w, r, v)
+}
+
+func writeCSV(w http.ResponseWriter, filename string, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+
+	out := csv.NewWriter(w)
+	out.WriteAll(records)
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+// writeMarkdown writes records as a table, the first record being the header.
+func writeMarkdown(w http.ResponseWriter, records [][]string) {
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+
+	for i, record := range records {
+		cells := make([]string, len(record))
+		for j, cell := range record {
+			cells[j] = markdownEscaper.Replace(cell)
+		}
+		fmt.Fprintln(w, "| "+strings.Join(cells, " | ")+" |")
+
+		if i == 0 {
+			fmt.Fprintln(w, strings.Repeat("| --- ", len(record))+"|")
+		}
+	}
+}
+
+func wantsCalendar(r *http.Request) bool {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format == "ics"
+	}
+	return negotiate(r, "application/json", "text/calendar") == "text/calendar"
+}
+
+// negotiate picks the offer the Accept header of r prefers, by q-value and
+// then by the order of offers. Each offer takes the q-value of the most
+// specific media range matching it, so that "text/*;q=0.5, */*" still
+// prefers JSON over CSV. It is the first offer when there is no Accept
+// header, and "" when the header accepts none of the offers.
+func negotiate(r *http.Request, offers ...string) string {
+	header := r.Header.Get("Accept")
+	if header == "" {
+		return offers[0]
+	}
+
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		q, specificity := 0.0, -1
+		for _, mediaRange := range strings.Split(header, ",") {
+			params := strings.Split(mediaRange, ";")
+			rangeQ := 1.0
+			for _, param := range params[1:] {
+				name, value := strings.TrimSpace(param), ""
+				if i := strings.Index(name, "="); i >= 0 {
+					name, value = strings.TrimSpace(name[:i]), strings.TrimSpace(name[i+1:])
+				}
+				if name == "q" {
+					if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+						rangeQ = parsed
+					}
+				}
+			}
+
+			if s := mediaRangeMatch(strings.TrimSpace(params[0]), offer); s > specificity {
+				q, specificity = rangeQ, s
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+
+	return best
+}
+
+// mediaRangeMatch is how specifically mediaRange matches mediaType, from 0
+// for */* to 2 for the type itself, or -1 when it does not match.
+func mediaRangeMatch(mediaRange string, mediaType string) int {
+	mediaRange = strings.ToLower(mediaRange)
+	switch {
+	case mediaRange == mediaType:
+		return 2
+	case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*")):
+		return 1
+	case mediaRange == "*/*":
+		return 0
+	}
+	return -1
+}
+
+// responseFormat is the format a response is negotiated to, telling apart
+// the validators of the representations of one URL.
+func responseFormat(r *http.Request) string {
+	if wantsCalendar(r) {
+		return "ics"
+	}
+	if format := tableFormat(r); format != "" {
+		return format
+	}
+	return "json"
+}
+
+// notModified sets the ETag and Last-Modified of a response built from
+// timetables of the given freshness, variant being whatever else the
+// response depends on beyond its URL. It answers 304 and returns true when
+// the client's copy is still current.
+//
+// The ETags are weak since bodies carry their age and calendars their
+// DTSTAMP, which change while the timetables do not.
+func notModified(w http.ResponseWriter, r *http.Request, variant string, freshness fft.Freshness) bool {
+	h := fnv.New64a()
+	io.WriteString(h, variant)
+	etag := `W/"` + strconv.FormatUint(freshness.Version, 36) + "-" + strconv.FormatUint(h.Sum64(), 36) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Add("Vary", "Accept")
+	if !freshness.Modified.IsZero() {
+		w.Header().Set("Last-Modified", freshness.Modified.UTC().Format(http.TimeFormat))
+	}
+
+	// If-None-Match takes precedence, RFC 7232 section 6
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		if !etagMatches(match, etag) {
+			return false
+		}
+	} else {
+		since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if err != nil || freshness.Modified.IsZero() || freshness.Modified.Truncate(time.Second).After(since) {
+			return false
+		}
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches compares the If-None-Match list with etag weakly.
+func etagMatches(match string, etag string) bool {
+	for _, tag := range strings.Split(match, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+func writeCalendar(w http.ResponseWriter, filename string, name string, events []fft.CalendarEvent) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+
+	fft.WriteCalendar(w, name, events)
+}
+
+func snapshotFreshness(snapshot *fft.Snapshot) fft.Freshness {
+	return fft.Freshness{Version: snapshot.Version, Modified: snapshot.ModifiedAt}
+}
+
+func freeOrBusy(free bool) string {
+	if free {
+		return "free"
+	}
+	return "busy"
+}
+
+// feedWeeks is the academic week of now and the next one, or the default
+// week outside term.
+func feedWeeks(now time.Time) []int {
+	week, err := fft.WeekAt(now)
+	if err != nil {
+		return []int{fft.DefaultWeek}
+	}
+
+	if week == fft.LastWeek {
+		return []int{week}
+	}
+	return []int{week, week + 1}
+}
+
+// eventStream writes events as Server-Sent Events, or as newline delimited
+// JSON when the client accepts application/x-ndjson. Headers are only sent
+// with the first event, so errors can still be rendered before that. Once
+// the client disconnects, events are dropped.
+type eventStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	ctx     context.Context
+	ndjson  bool
+	started bool
+}
+
+func newEventStream(w http.ResponseWriter, r *http.Request) (*eventStream, bool) {
+	flusher, ok := w.(http.Flusher)
+	ndjson := negotiate(r, "text/event-stream", "application/x-ndjson") == "application/x-ndjson"
+	return &eventStream{w: w, flusher: flusher, ctx: r.Context(), ndjson: ndjson}, ok
+}
+
+func (es *eventStream) send(event string, v interface{}) {
+	if es.ctx.Err() != nil {
+		return
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("streaming a %s event: %v", event, err)
+		return
+	}
+
+	if !es.started {
+		if es.ndjson {
+			es.w.Header().Set("Content-Type", "application/x-ndjson")
+		} else {
+			es.w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		}
+		es.w.Header().Set("Cache-Control", "no-cache")
+		es.w.WriteHeader(200)
+		es.started = true
+	}
+
+	if es.ndjson {
+		fmt.Fprintf(es.w, "{\"event\":%q,\"data\":%s}\n", event, data)
+	} else {
+		fmt.Fprintf(es.w, "event: %s\ndata: %s\n\n", event, data)
+	}
+
+	es.flusher.Flush()
+}
+
+func durationFromEnv(name string, d *time.Duration) {
+	if value := os.Getenv(name); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatal("$"+name+" must be a duration such as 30m: ", err)
+		}
+		*d = parsed
+	}
+}
+
+func intFromEnv(name string, i *int) {
+	if value := os.Getenv(name); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			log.Fatal("$"+name+" must be a number: ", err)
+		}
+		*i = parsed
+	}
+}
 
 //============================
 // Helpers (end)
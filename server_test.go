@@ -0,0 +1,775 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	e "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	fft "github.com/thailekha/rooms-checker-go/api"
+)
+
+func TestMain(m *testing.M) {
+	render.Decode = decodeStrict
+	render.Respond = respond
+	fft.Fetch = stubs.fetch
+	os.Exit(runInScratchDir(m))
+}
+
+// runInScratchDir runs the tests from an empty directory that is removed
+// afterwards, so that nothing they fetch or write lands in the tree.
+func runInScratchDir(m *testing.M) int {
+	wd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	dir, err := os.MkdirTemp("", "rooms-checker")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	defer os.Chdir(wd)
+
+	return m.Run()
+}
+
+// newTimetable is a week of room with every slot free but the busy ones,
+// given as "weekday time".
+func newTimetable(room string, week int, busy ...string) *fft.Timetable {
+	tt := &fft.Timetable{Room: room, Week: week, Days: map[string][]fft.Slot{}}
+
+	for _, weekday := range fft.GetWeekdays() {
+		for _, t := range fft.GetSupportedTimes() {
+			slot := fft.Slot{Time: t, Free: true}
+			if fft.Contains(weekday+" "+t, busy) {
+				slot = fft.Slot{Time: t, Event: &fft.Event{Module: "M" + strings.Replace(t, ":", "", 1)}}
+			}
+			tt.Days[weekday] = append(tt.Days[weekday], slot)
+		}
+	}
+
+	return tt
+}
+
+// stubs answers the fetches of the timetable cache in place of the college
+// website. Fetches a test leaves behind may still be running when the next
+// test starts, so the stub stays and only its timetables change.
+var stubs = &stubFetch{}
+
+type stubFetch struct {
+	mu  sync.Mutex
+	tts []*fft.Timetable
+}
+
+func (s *stubFetch) fetch(room string, week int) (*fft.Timetable, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tt := range s.tts {
+		if tt.Room == room && tt.Week == week {
+			return tt, nil
+		}
+	}
+	return nil, e.New(room + ": no response")
+}
+
+// stubTimetables empties the timetable cache and answers its fetches with
+// the given timetables for the rest of the test, failing for the rooms that
+// have none.
+func stubTimetables(t *testing.T, tts ...*fft.Timetable) {
+	stubs.mu.Lock()
+	stubs.tts = tts
+	stubs.mu.Unlock()
+
+	fft.ClearCache()
+	t.Cleanup(fft.ClearCache)
+}
+
+// newRequest is a request made by user, anonymous when empty, with a JSON
+// body when body is not empty.
+func newRequest(method string, target string, user string, body string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	if user != "" {
+		r = r.WithContext(context.WithValue(r.Context(), userCtxKey, user))
+	}
+	return r
+}
+
+// serve routes r to handler mounted at pattern, so that URL parameters are
+// set, and returns the response.
+func serve(handler http.HandlerFunc, pattern string, r *http.Request) *httptest.ResponseRecorder {
+	router := chi.NewRouter()
+	router.MethodFunc(r.Method, pattern, handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	return w
+}
+
+func TestMyHistory(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", fft.DefaultWeek))
+
+	// the history outlives the test, so the users are new each run
+	run := strconv.FormatInt(time.Now().UnixNano(), 36)
+	alice, bob := "alice-"+run, "bob-"+run
+
+	search := fft.HistoryEntry{User: alice, Weekday: "monday", Week: fft.DefaultWeek, StartTime: "9:15", EndTime: "10:15", Rooms: []string{"IT101"}}
+	alices := strconv.FormatInt(fft.RecordSearch(withNow(search)).ID, 10)
+	search.User = bob
+	fft.RecordSearch(withNow(search))
+
+	cases := []struct {
+		name    string
+		handler http.HandlerFunc
+		method  string
+		pattern string
+		target  string
+		user    string
+		want    int
+	}{
+		{"lists own searches", getMyHistory, "GET", "/", "/", alice, 200},
+		{"needs a subject to list", getMyHistory, "GET", "/", "/", "", 401},
+		{"reruns own search", rerunMySearch, "POST", "/{id}/rerun", "/" + alices + "/rerun", alice, 200},
+		{"hides others' searches from rerun", rerunMySearch, "POST", "/{id}/rerun", "/" + alices + "/rerun", bob, 404},
+		{"rejects malformed IDs", rerunMySearch, "POST", "/{id}/rerun", "/x/rerun", alice, 404},
+		{"hides others' searches from delete", deleteMySearch, "DELETE", "/{id}", "/" + alices, bob, 404},
+		{"deletes own search", deleteMySearch, "DELETE", "/{id}", "/" + alices, alice, 204},
+		{"deletes only once", deleteMySearch, "DELETE", "/{id}", "/" + alices, alice, 404},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := serve(c.handler, c.pattern, newRequest(c.method, c.target, c.user, ""))
+			if w.Code != c.want {
+				t.Errorf("status %d, want %d: %s", w.Code, c.want, w.Body)
+			}
+		})
+	}
+
+	entries, _ := fft.QueryHistory(fft.HistoryFilter{User: alice})
+	if len(entries) != 1 || entries[0].Rooms[0] != "IT101" {
+		t.Errorf("alice's history is %+v, want only the rerun", entries)
+	}
+	if w := serve(getMyHistory, "/", newRequest("GET", "/", alice, "")); strings.Contains(w.Body.String(), bob) {
+		t.Errorf("alice's history lists bob's searches: %s", w.Body)
+	}
+}
+
+func withNow(entry fft.HistoryEntry) fft.HistoryEntry {
+	entry.Time = time.Now()
+	return entry
+}
+
+func TestGetTimetable(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", 12, "monday 9:15"))
+
+	cases := []struct {
+		name   string
+		target string
+		want   int
+		body   string
+	}{
+		{"serves a room's week", "/IT101/timetable?week=12", 200, `"weekStart":"2017-11-20"`},
+		{"tells what a slot is booked for", "/IT101/timetable?week=12", 200, `{"time":"9:15","free":false,"event":{"module":"M915"}}`},
+		{"rejects unknown rooms", "/XX999/timetable", 404, `"type":"unknown_room"`},
+		{"rejects weeks out of range", "/IT101/timetable?week=99", 400, `"code":1000`},
+		{"rejects malformed weeks", "/IT101/timetable?week=x", 400, `"code":1000`},
+		{"fails when the college website does", "/IT102/timetable?week=12", 503, `"type":"upstream_unavailable"`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := serve(getTimetable, "/{room}/timetable", newRequest("GET", c.target, "", ""))
+			if w.Code != c.want || !strings.Contains(w.Body.String(), c.body) {
+				t.Errorf("got %d %s, want %d with %s", w.Code, w.Body, c.want, c.body)
+			}
+		})
+	}
+}
+
+func TestCheckFreeTimesMinDuration(t *testing.T) {
+	stubTimetables(t,
+		newTimetable("IT101", fft.DefaultWeek, "monday 11:15"),
+		newTimetable("IT102", fft.DefaultWeek, "monday 10:15"),
+	)
+
+	cases := []struct {
+		name        string
+		minDuration int
+		want        []fft.RoomTimes
+	}{
+		{"answers every free time without minDuration", 0, []fft.RoomTimes{{Room: "IT101", Times: []string{"10:15", "12:15", "9:15"}}, {Room: "IT102", Times: []string{"11:15", "12:15", "9:15"}}}},
+		{"keeps the times in long enough blocks", 120, []fft.RoomTimes{{Room: "IT101", Times: []string{"10:15", "9:15"}}, {Room: "IT102", Times: []string{"11:15", "12:15"}}}},
+		{"drops the rooms without such a block", 180, []fft.RoomTimes{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			body := `{"weekday":"monday","startTime":"9:15","endTime":"12:15","rooms":["IT101","IT102"],"minDuration":` + strconv.Itoa(c.minDuration) + `}`
+			w := serve(checkFreeTimes, "/", newRequest("POST", "/", "alice", body))
+
+			var response FreeTimesResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || w.Code != 200 {
+				t.Fatalf("got %d %s", w.Code, w.Body)
+			}
+			sort.Slice(response.Rooms, func(i, j int) bool { return response.Rooms[i].Room < response.Rooms[j].Room })
+			if !reflect.DeepEqual(response.Rooms, c.want) {
+				t.Errorf("rooms %+v, want %+v", response.Rooms, c.want)
+			}
+		})
+	}
+}
+
+func TestFreeTimesValidation(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", fft.DefaultWeek))
+
+	cases := []struct {
+		name   string
+		body   string
+		want   int
+		fields string
+	}{
+		{"accepts a valid search", `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":["IT101"]}`, 200, ""},
+		{"rejects a bad weekday", `{"weekday":"sunday","startTime":"9:15","endTime":"10:15","rooms":["IT101"]}`, 400, `[{"field":"weekday"`},
+		{"rejects a window ending before it starts", `{"weekday":"monday","startTime":"12:15","endTime":"10:15","rooms":["IT101"]}`, 400, `[{"field":"endTime","reason":"must not be before startTime"}]`},
+		{"rejects unsupported times", `{"weekday":"monday","startTime":"9:00","endTime":"10:15","rooms":["IT101"]}`, 400, `[{"field":"startTime"`},
+		{"rejects unknown rooms", `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":["IT101","XX999"]}`, 400, `[{"field":"rooms[1]","reason":"is not a known room: XX999"}]`},
+		{"rejects no rooms", `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":[]}`, 400, `[{"field":"rooms","reason":"must list at least one room"}]`},
+		{"rejects weeks out of range", `{"week":99,"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":["IT101"]}`, 400, `[{"field":"week"`},
+		{"rejects a negative minDuration", `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":["IT101"],"minDuration":-1}`, 400, `[{"field":"minDuration"`},
+		{"rejects an unknown mode", `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":["IT101"],"mode":"some"}`, 400, `[{"field":"mode"`},
+		{"rejects minRooms beyond the rooms", `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":["IT101"],"mode":"atleast","minRooms":2}`, 400, `[{"field":"minRooms"`},
+		{"counts repeated rooms once towards minRooms", `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":["IT101","IT101"],"mode":"atleast","minRooms":2}`, 400, `[{"field":"minRooms"`},
+		{"lists every failing field", `{"weekday":"sunday","startTime":"9:15","endTime":"10:15","rooms":[]}`, 400, `[{"field":"weekday","reason":"must be one of monday, tuesday, wednesday, thursday, friday"},{"field":"rooms","reason":"must list at least one room"}]`},
+		{"rejects unknown fields", `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":["IT101"],"room":"IT102"}`, 400, `[{"field":"room","reason":"is not a known field"}]`},
+		{"rejects fields of the wrong type", `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":"IT101"}`, 400, `[{"field":"rooms","reason":"must be an array"}]`},
+		{"rejects malformed JSON", `{"weekday":`, 400, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := serve(checkFreeTimes, "/", newRequest("POST", "/", "alice", c.body))
+			if w.Code != c.want || !strings.Contains(w.Body.String(), c.fields) {
+				t.Errorf("got %d %s, want %d with %s", w.Code, w.Body, c.want, c.fields)
+			}
+			if w.Code == 400 && c.fields != "" && !strings.Contains(w.Body.String(), `"code":1001`) {
+				t.Errorf("field errors not coded validation_failed: %s", w.Body)
+			}
+		})
+	}
+}
+
+func TestCheckFreeTimesAllMode(t *testing.T) {
+	stubTimetables(t,
+		newTimetable("IT101", fft.DefaultWeek, "monday 10:15"),
+		newTimetable("IT102", fft.DefaultWeek),
+	)
+
+	cases := []struct {
+		name  string
+		rooms string
+		want  string
+	}{
+		{"finds the times every room is free", `["IT101","IT102"]`, `"common":[{"time":"9:15","rooms":["IT101","IT102"]}]`},
+		{"counts repeated rooms once", `["IT101","IT102","IT101"]`, `"common":[{"time":"9:15","rooms":["IT101","IT102"]}]`},
+		{"finds none with an unknown room when explaining", `["IT101","IT102","XX999"]`, `{"room":"XX999","status":"not-a-teaching-room"}`},
+		{"finds none with an unavailable room", `["IT101","IT102","IT103"]`, `"unavailable":["IT103"]`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			body := `{"weekday":"monday","startTime":"9:15","endTime":"10:15","mode":"all","explain":true,"rooms":` + c.rooms + `}`
+			w := serve(checkFreeTimes, "/", newRequest("POST", "/", "alice", body))
+			if w.Code != 200 || !strings.Contains(w.Body.String(), c.want) {
+				t.Errorf("got %d %s, want %s", w.Code, w.Body, c.want)
+			}
+			if strings.Contains(c.want, "common") != strings.Contains(w.Body.String(), `"common"`) {
+				t.Errorf("got %s, want common times only with %s", w.Body, c.want)
+			}
+		})
+	}
+}
+
+func TestStreamFreeTimes(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", fft.DefaultWeek), newTimetable("IT102", fft.DefaultWeek, "monday 9:15"))
+
+	search := `{"weekday":"monday","startTime":"9:15","endTime":"9:15","rooms":["IT101","IT102"]}`
+
+	cases := []struct {
+		name        string
+		accept      string
+		body        string
+		cancelled   bool
+		want        int
+		contentType string
+		events      []string
+	}{
+		{"streams rooms then a summary as SSE", "", search, false, 200, "text/event-stream; charset=utf-8", []string{"event: room\n", "event: room\n", "event: summary\n"}},
+		{"streams NDJSON when accepted", "application/x-ndjson", search, false, 200, "application/x-ndjson", []string{`{"event":"room"`, `{"event":"room"`, `{"event":"summary"`}},
+		{"renders errors before the first event", "", `{"weekday":"sunday"}`, false, 400, "application/json", nil},
+		{"writes nothing once the client is gone", "", search, true, 200, "", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := newRequest("POST", "/", "alice", c.body)
+			r.Header.Set("Accept", c.accept)
+			if c.cancelled {
+				ctx, cancel := context.WithCancel(r.Context())
+				cancel()
+				r = r.WithContext(ctx)
+			}
+
+			w := serve(streamFreeTimes, "/", r)
+			if w.Code != c.want || w.Header().Get("Content-Type") != c.contentType {
+				t.Fatalf("got %d %q, want %d %q", w.Code, w.Header().Get("Content-Type"), c.want, c.contentType)
+			}
+
+			body := w.Body.String()
+			if c.want == 200 && len(c.events) == 0 && body != "" {
+				t.Errorf("wrote %q after the client disconnected", body)
+			}
+			for _, event := range c.events {
+				i := strings.Index(body, event)
+				if i < 0 {
+					t.Fatalf("missing %q in %q", event, w.Body)
+				}
+				body = body[i+len(event):]
+			}
+		})
+	}
+}
+
+func TestGetFeed(t *testing.T) {
+	weeks := feedWeeks(time.Now())
+	var tts []*fft.Timetable
+	for _, week := range weeks {
+		tts = append(tts, newTimetable("IT101", week))
+	}
+	stubTimetables(t, tts...)
+
+	feed, err := fft.IssueFeed("alice", []string{"IT101", "IT102"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	revoked, _ := fft.IssueFeed("alice", []string{"IT101"})
+	fft.RevokeFeed("alice", revoked.ID)
+	unavailable, _ := fft.IssueFeed("alice", []string{"IT102"})
+	defer fft.RevokeFeed("alice", feed.ID)
+	defer fft.RevokeFeed("alice", unavailable.ID)
+
+	cases := []struct {
+		name    string
+		token   string
+		want    int
+		body    string
+		without string
+	}{
+		{"serves the free time of the feed's rooms", feed.Token + ".ics", 200, fmt.Sprintf("UID:free-it101-w%d-monday-915@rooms-checker-go", weeks[0]), ""},
+		{"leaves out rooms that cannot be fetched", feed.Token + ".ics", 200, "X-WR-CALNAME:Free rooms: IT101\\, IT102", "UID:free-it102"},
+		{"rejects revoked feeds", revoked.Token + ".ics", 404, `"type":"feed_not_found"`, ""},
+		{"rejects made up tokens", "abc.def.ics", 404, `"type":"feed_not_found"`, ""},
+		{"answers 503 when no room can be fetched", unavailable.Token + ".ics", 503, `"type":"upstream_unavailable"`, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := serve(getFeed, "/feeds/{token}", newRequest("GET", "/feeds/"+c.token, "", ""))
+			if w.Code != c.want || !strings.Contains(w.Body.String(), c.body) {
+				t.Errorf("got %d %s, want %d with %s", w.Code, w.Body, c.want, c.body)
+			}
+			if c.without != "" && strings.Contains(w.Body.String(), c.without) {
+				t.Errorf("got %s, want no %s", w.Body, c.without)
+			}
+			if w.Code == 503 && w.Header().Get("Retry-After") == "" {
+				t.Error("503 without Retry-After")
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{"application/json", "text/csv", "text/markdown"}
+
+	cases := []struct {
+		accept string
+		want   string
+	}{
+		{"", "application/json"},
+		{"text/csv", "text/csv"},
+		{"text/csv; charset=utf-8", "text/csv"},
+		{"TEXT/CSV", "text/csv"},
+		{"*/*", "application/json"},
+		{"text/*", "text/csv"},
+		{"text/csv;q=0.5, application/json", "application/json"},
+		{"application/json;q=0.1, text/markdown;q=0.9, text/csv;q=0.5", "text/markdown"},
+		{"text/*;q=0.5, */*", "application/json"},
+		{"*/*;q=0.1, text/markdown", "text/markdown"},
+		{"text/csv;q=0, */*", "application/json"},
+		{"text/html,application/xhtml+xml,*/*;q=0.8", "application/json"},
+		{"image/png", ""},
+	}
+
+	for _, c := range cases {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", c.accept)
+		if got := negotiate(r, offers...); got != c.want {
+			t.Errorf("Accept %q: got %q, want %q", c.accept, got, c.want)
+		}
+	}
+}
+
+func TestFreeTimesTable(t *testing.T) {
+	stubTimetables(t,
+		newTimetable("IT101", fft.DefaultWeek, "monday 10:15"),
+		newTimetable("IT102", fft.DefaultWeek, "monday 9:15", "monday 10:15"),
+	)
+
+	cases := []struct {
+		name   string
+		accept string
+		rooms  string
+		want   string
+	}{
+		{"has a row per room, busy ones included", "text/csv", `["IT101","IT102"]`, "room,9:15,10:15\nIT101,free,busy\nIT102,busy,busy\n"},
+		{"has a row per distinct room", "text/csv", `["IT102","IT101","IT102"]`, "room,9:15,10:15\nIT102,busy,busy\nIT101,free,busy\n"},
+		{"marks unavailable and unknown rooms", "text/csv", `["IT101","IT103","XX999"]`, "room,9:15,10:15\nIT101,free,busy\nIT103,unavailable,unavailable\nXX999,unknown,unknown\n"},
+		{"answers JSON when CSV is less preferred", "text/csv;q=0.5, application/json", `["IT101"]`, `{"rooms":`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			body := `{"weekday":"monday","startTime":"9:15","endTime":"10:15","explain":true,"rooms":` + c.rooms + `}`
+			r := newRequest("POST", "/", "alice", body)
+			r.Header.Set("Accept", c.accept)
+
+			w := serve(checkFreeTimes, "/", r)
+			if got := strings.Replace(w.Body.String(), "\r\n", "\n", -1); w.Code != 200 || !strings.HasPrefix(got, c.want) {
+				t.Errorf("got %d %q, want %q", w.Code, got, c.want)
+			}
+		})
+	}
+}
+
+func TestMatrixTable(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", fft.DefaultWeek, "tuesday 9:15"))
+
+	body := `{"days":["monday","tuesday"],"startTime":"9:15","endTime":"10:15","rooms":["IT101","IT102"]}`
+	w := serve(checkMatrix, "/", newRequest("POST", "/?format=csv", "alice", body))
+
+	want := "room,monday 9:15,monday 10:15,tuesday 9:15,tuesday 10:15\n" +
+		"IT101,free,free,busy,free\n" +
+		"IT102,unavailable,unavailable,unavailable,unavailable\n"
+	if got := strings.Replace(w.Body.String(), "\r\n", "\n", -1); w.Code != 200 || got != want {
+		t.Errorf("got %d %q, want %q", w.Code, got, want)
+	}
+}
+
+func TestCors(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", fft.DefaultWeek))
+	router := newRouter()
+
+	cases := []struct {
+		name   string
+		method string
+		header string
+		want   string
+	}{
+		{"exposes the headers clients read", "GET", "Access-Control-Expose-Headers", "Link, Www-Authenticate, Etag, Last-Modified, Location, Retry-After"},
+		{"allows conditional requests", "OPTIONS", "Access-Control-Allow-Headers", "If-None-Match"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest(c.method, "/api/public/rooms", nil)
+			r.Header.Set("Origin", "https://example.com")
+			if c.method == "OPTIONS" {
+				r.Header.Set("Access-Control-Request-Method", "GET")
+				r.Header.Set("Access-Control-Request-Headers", "If-None-Match")
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+			if got := w.Header().Get(c.header); got != c.want {
+				t.Errorf("%s: %q, want %q", c.header, got, c.want)
+			}
+		})
+	}
+}
+
+func TestConditionalGet(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", 12))
+
+	first := serve(getTimetable, "/{room}/timetable", newRequest("GET", "/IT101/timetable?week=12", "", ""))
+	etag, modified := first.Header().Get("ETag"), first.Header().Get("Last-Modified")
+	if first.Code != 200 || !strings.HasPrefix(etag, `W/"`) || modified == "" {
+		t.Fatalf("got %d with ETag %q and Last-Modified %q", first.Code, etag, modified)
+	}
+	lastModified, _ := http.ParseTime(modified)
+	earlier := lastModified.Add(-time.Hour).Format(http.TimeFormat)
+
+	cases := []struct {
+		name    string
+		target  string
+		headers map[string]string
+		want    int
+	}{
+		{"answers 304 to a matching If-None-Match", "/IT101/timetable?week=12", map[string]string{"If-None-Match": etag}, 304},
+		{"compares ETags weakly", "/IT101/timetable?week=12", map[string]string{"If-None-Match": strings.TrimPrefix(etag, "W/")}, 304},
+		{"matches any ETag of a list", "/IT101/timetable?week=12", map[string]string{"If-None-Match": `"other", ` + etag}, 304},
+		{"matches *", "/IT101/timetable?week=12", map[string]string{"If-None-Match": "*"}, 304},
+		{"answers 200 to another ETag", "/IT101/timetable?week=12", map[string]string{"If-None-Match": `W/"other"`}, 200},
+		{"varies the ETag with the format", "/IT101/timetable?week=12&format=ics", map[string]string{"If-None-Match": etag}, 200},
+		{"answers 304 when unmodified since", "/IT101/timetable?week=12", map[string]string{"If-Modified-Since": modified}, 304},
+		{"answers 200 when modified since", "/IT101/timetable?week=12", map[string]string{"If-Modified-Since": earlier}, 200},
+		{"ignores malformed dates", "/IT101/timetable?week=12", map[string]string{"If-Modified-Since": "yesterday"}, 200},
+		{"prefers If-None-Match to If-Modified-Since", "/IT101/timetable?week=12", map[string]string{"If-None-Match": `W/"other"`, "If-Modified-Since": modified}, 200},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := newRequest("GET", c.target, "", "")
+			for name, value := range c.headers {
+				r.Header.Set(name, value)
+			}
+
+			w := serve(getTimetable, "/{room}/timetable", r)
+			if w.Code != c.want {
+				t.Fatalf("got %d, want %d", w.Code, c.want)
+			}
+			if w.Code == 304 && (w.Body.Len() > 0 || w.Header().Get("ETag") != etag) {
+				t.Errorf("304 with body %q and ETag %q", w.Body, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestConditionalGetAfterChange(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", 12))
+
+	first := serve(getTimetable, "/{room}/timetable", newRequest("GET", "/IT101/timetable?week=12", "", ""))
+	etag := first.Header().Get("ETag")
+
+	stubTimetables(t, newTimetable("IT101", 12, "monday 9:15"))
+
+	r := newRequest("GET", "/IT101/timetable?week=12", "", "")
+	r.Header.Set("If-None-Match", etag)
+	w := serve(getTimetable, "/{room}/timetable", r)
+	if w.Code != 200 || w.Header().Get("ETag") == etag {
+		t.Errorf("got %d with ETag %s after the timetable changed, want 200 with a new ETag", w.Code, w.Header().Get("ETag"))
+	}
+}
+
+func TestGetFreeTimes(t *testing.T) {
+	stubTimetables(t,
+		newTimetable("IT101", fft.DefaultWeek, "monday 9:15"),
+		newTimetable("IT102", fft.DefaultWeek),
+	)
+
+	cases := []struct {
+		name     string
+		target   string
+		want     int
+		location string
+	}{
+		{"redirects to the canonical query", "/?rooms=it102,IT101&to=10:15&from=9:15&day=monday", 302, "/?day=monday&from=9%3A15&rooms=IT101&rooms=IT102&to=10%3A15"},
+		{"leaves defaults out of the canonical query", "/?day=monday&from=9:15&to=10:15&rooms=IT101&type=any&week=" + strconv.Itoa(fft.DefaultWeek), 302, "/?day=monday&from=9%3A15&rooms=IT101&to=10%3A15"},
+		{"answers canonical queries", "/?day=monday&from=9%3A15&rooms=IT101&rooms=IT102&to=10%3A15", 200, ""},
+		{"rejects bad queries", "/?day=monday&from=9:15&to=10:15&rooms=IT101&minDuration=x", 400, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := serve(getFreeTimes, "/", newRequest("GET", c.target, "u-get", ""))
+			if w.Code != c.want || w.Header().Get("Location") != c.location {
+				t.Errorf("got %d to %q, want %d to %q: %s", w.Code, w.Header().Get("Location"), c.want, c.location, w.Body)
+			}
+		})
+	}
+}
+
+func TestGetFreeTimesRecordsOnlySentResults(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", fft.DefaultWeek))
+	user := fmt.Sprintf("u-revalidate-%d", time.Now().UnixNano())
+	target := "/?day=monday&from=9%3A15&rooms=IT101&to=10%3A15"
+
+	first := serve(getFreeTimes, "/", newRequest("GET", target, user, ""))
+	if first.Code != 200 || first.Header().Get("Cache-Control") != "private, no-cache" {
+		t.Fatalf("got %d with Cache-Control %q", first.Code, first.Header().Get("Cache-Control"))
+	}
+
+	for i := 0; i < 3; i++ {
+		r := newRequest("GET", target, user, "")
+		r.Header.Set("If-None-Match", first.Header().Get("ETag"))
+		if w := serve(getFreeTimes, "/", r); w.Code != 304 {
+			t.Fatalf("revalidation %d: got %d", i, w.Code)
+		}
+	}
+
+	if searches, _ := fft.QueryHistory(fft.HistoryFilter{User: user}); len(searches) != 1 {
+		t.Errorf("recorded %d searches, want the one sent", len(searches))
+	}
+}
+
+func TestCheckFreeTimesBatch(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", fft.DefaultWeek, "monday 9:15"))
+
+	ok := `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":["IT101"]}`
+	invalid := `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":[]}`
+	unavailable := `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":["IT102"]}`
+
+	cases := []struct {
+		name     string
+		body     string
+		want     int
+		statuses []int
+	}{
+		{"answers every search", "[" + ok + "," + ok + "]", 200, []int{200, 200}},
+		{"succeeds when the last search fails", "[" + ok + "," + unavailable + "]", 200, []int{200, 503}},
+		{"succeeds when the last search is invalid", "[" + ok + "," + unavailable + "," + invalid + "]", 200, []int{200, 503, 400}},
+		{"succeeds when every search fails", "[" + invalid + "," + unavailable + "]", 200, []int{400, 503}},
+		{"fails a search with an unknown field alone", "[" + ok + `,{"weekday":"monday","colour":"red"}]`, 200, []int{200, 400}},
+		{"fails a null search alone", "[null," + ok + "]", 200, []int{400, 200}},
+		{"fails a search that is not an object alone", "[" + ok + `,"IT101"]`, 200, []int{200, 400}},
+		{"fails a search with a mistyped field alone", `[{"weekday":1},` + ok + "]", 200, []int{400, 200}},
+		{"rejects empty batches", "[]", 400, nil},
+		{"rejects bodies that are not lists", ok, 400, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := serve(checkFreeTimesBatch, "/", newRequest("POST", "/", "u-batch", c.body))
+			if w.Code != c.want {
+				t.Fatalf("got %d %s, want %d", w.Code, w.Body, c.want)
+			}
+			if c.statuses == nil {
+				return
+			}
+
+			var items []struct {
+				Status int             `json:"status"`
+				Result json.RawMessage `json:"result"`
+				Error  json.RawMessage `json:"error"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &items); err != nil {
+				t.Fatalf("%v: %s", err, w.Body)
+			}
+
+			var statuses []int
+			for _, item := range items {
+				statuses = append(statuses, item.Status)
+				if (item.Status == 200) != (item.Result != nil) || (item.Status == 200) == (item.Error != nil) {
+					t.Errorf("item %+v has the wrong one of result and error", item)
+				}
+			}
+			if !reflect.DeepEqual(statuses, c.statuses) {
+				t.Errorf("statuses %v, want %v", statuses, c.statuses)
+			}
+		})
+	}
+}
+
+func TestJobs(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", fft.DefaultWeek))
+
+	cases := []struct {
+		name   string
+		rooms  string
+		status string
+		total  int
+		body   string
+	}{
+		{"finishes with the result", `["IT101"]`, fft.JobDone, 1, `"result":{"rooms":[{"room":"IT101"`},
+		{"counts each room once, found or not", `["IT101", "IT102", "IT101"]`, fft.JobDone, 2, `"unavailable":["IT102"]`},
+		{"answers 200 for failed jobs", `["IT102"]`, fft.JobFailed, 1, `"error":{"status":"Service unavailable","code":5000`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			user := fmt.Sprintf("u-job-%d", time.Now().UnixNano())
+			body := `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":` + c.rooms + `}`
+
+			w := serve(createJob, "/jobs", newRequest("POST", "/jobs", user, body))
+			if w.Code != 202 || !strings.HasPrefix(w.Header().Get("Location"), "/jobs/") || !strings.Contains(w.Body.String(), `"notice":"`+jobNotice+`"`) {
+				t.Fatalf("got %d to %q: %s", w.Code, w.Header().Get("Location"), w.Body)
+			}
+
+			var job JobResponse
+			for deadline := time.Now().Add(5 * time.Second); job.Status != c.status; {
+				if time.Now().After(deadline) {
+					t.Fatalf("job still %s, want %s", job.Status, c.status)
+				}
+				time.Sleep(time.Millisecond)
+
+				w = serve(getJob, "/jobs/{id}", newRequest("GET", w.Header().Get("Location"), user, ""))
+				if w.Code != 200 {
+					t.Fatalf("got %d %s", w.Code, w.Body)
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+					t.Fatal(err)
+				}
+				if job.Status == fft.JobRunning && w.Header().Get("Retry-After") == "" {
+					t.Error("running job without Retry-After")
+				}
+			}
+
+			if job.Total != c.total || job.Done != c.total || !strings.Contains(w.Body.String(), c.body) || strings.Contains(w.Body.String(), "notice") {
+				t.Errorf("got %s, want %d of %d done with %s", w.Body, c.total, c.total, c.body)
+			}
+		})
+	}
+}
+
+func TestJobNotFound(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", fft.DefaultWeek))
+
+	w := serve(createJob, "/jobs", newRequest("POST", "/jobs", "u-job-owner", `{"weekday":"monday","startTime":"9:15","endTime":"10:15","rooms":["IT101"]}`))
+	location := w.Header().Get("Location")
+
+	cases := []struct {
+		name   string
+		method string
+		target string
+		user   string
+		want   int
+	}{
+		{"hides jobs from other users", "GET", location, "u-job-other", 404},
+		{"rejects malformed IDs", "GET", "/jobs/x", "u-job-owner", 404},
+		{"lets only the owner cancel", "DELETE", location, "u-job-other", 404},
+		{"cancels jobs", "DELETE", location, "u-job-owner", 204},
+		{"forgets cancelled jobs", "GET", location, "u-job-owner", 404},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			handler := getJob
+			if c.method == "DELETE" {
+				handler = cancelJob
+			}
+
+			w := serve(handler, "/jobs/{id}", newRequest(c.method, c.target, c.user, ""))
+			if w.Code != c.want || (c.want == 404 && !strings.Contains(w.Body.String(), `"type":"job_not_found"`) && c.method == "GET") {
+				t.Errorf("got %d %s, want %d", w.Code, w.Body, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"embed"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	fft "github.com/thailekha/rooms-checker-go/api"
+)
+
+// The HTML pages work without JavaScript or the Elm frontend, using only
+// public data.
+
+//go:embed web
+var webFiles embed.FS
+
+var pages = template.Must(template.New("").Funcs(template.FuncMap{
+	"has": fft.Contains,
+}).ParseFS(webFiles, "web/templates/*.html"))
+
+func staticFiles() http.Handler {
+	static, err := fs.Sub(webFiles, "web/static")
+	if err != nil {
+		log.Fatal(err)
+	}
+	return http.StripPrefix("/static/", http.FileServer(http.FS(static)))
+}
+
+type searchPage struct {
+	Weekdays []string
+	Times    []string
+	AllRooms []string
+	Request  FreeTimesRequest
+	Selected []string
+	Error    string
+	Result   *FreeTimesResponse
+	Table    [][]string
+}
+
+type timetablePage struct {
+	Room      string
+	Error     string
+	Timetable *TimetableResponse
+	Rows      []timetableRow
+	FirstWeek int
+	LastWeek  int
+	Previous  int
+	Next      int
+}
+
+type timetableRow struct {
+	Time  string
+	Slots []fft.Slot
+}
+
+// GET /?weekday=&start=&end=&rooms=
+// Searches every room when none is picked. Timetables are public, so the
+// page searches without signing in, and nobody's history records it.
+func getSearchPage(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page := searchPage{
+		Weekdays: fft.GetWeekdays(),
+		Times:    fft.GetSupportedTimes(),
+		AllRooms: fft.GetAllRooms(),
+		Request: FreeTimesRequest{
+			Weekday:   q.Get("weekday"),
+			StartTime: q.Get("start"),
+			EndTime:   q.Get("end"),
+			Rooms:     q["rooms"],
+		},
+		Selected: q["rooms"],
+	}
+
+	if page.Request.Weekday == "" {
+		if notModified(w, r, "html", fft.Freshness{Modified: startedAt}) {
+			return
+		}
+		writePage(w, http.StatusOK, "search.html", page)
+		return
+	}
+
+	if len(page.Request.Rooms) == 0 {
+		page.Request.Rooms = page.AllRooms
+	}
+
+	if err := page.Request.Bind(r); err != nil {
+		page.Error = err.Error()
+		writePage(w, http.StatusBadRequest, "search.html", page)
+		return
+	}
+
+	data := &page.Request
+	result, fftErr := fft.FindContext(r.Context(), data.Week, data.Weekday, data.StartTime, data.EndTime, data.Rooms, nil)
+	if fftErr != nil {
+		status := http.StatusUnprocessableEntity
+		if fftErr == fft.ErrUpstreamUnavailable {
+			status = http.StatusServiceUnavailable
+		}
+
+		page.Error = fftErr.Error()
+		writePage(w, status, "search.html", page)
+		return
+	}
+
+	if notModified(w, r, "html", result.Freshness) {
+		return
+	}
+
+	page.Result = freeTimesResponse(data, result)
+	page.Table = page.Result.Table()
+	writePage(w, http.StatusOK, "search.html", page)
+}
+
+// GET /rooms/{room}?week=
+func getTimetablePage(w http.ResponseWriter, r *http.Request) {
+	page := timetablePage{Room: chi.URLParam(r, "room"), FirstWeek: fft.FirstWeek, LastWeek: fft.LastWeek}
+
+	week, err := weekParam(r)
+	if err != nil {
+		page.Error = err.Error()
+		writePage(w, http.StatusBadRequest, "timetable.html", page)
+		return
+	}
+
+	snapshot, fftErr := fft.GetTimetable(page.Room, week)
+
+	if fftErr != nil {
+		status := http.StatusBadRequest
+		switch fftErr {
+		case fft.ErrUnknownRoom:
+			status = http.StatusNotFound
+		case fft.ErrUpstreamUnavailable:
+			status = http.StatusServiceUnavailable
+		}
+
+		page.Error = fftErr.Error()
+		writePage(w, status, "timetable.html", page)
+		return
+	}
+
+	if notModified(w, r, "html", snapshotFreshness(snapshot)) {
+		return
+	}
+
+	page.Timetable = NewTimetableResponse(snapshot)
+	page.Previous, page.Next = week-1, week+1
+
+	for _, time := range fft.GetSupportedTimes() {
+		row := timetableRow{Time: time}
+		for _, day := range page.Timetable.Days {
+			row.Slots = append(row.Slots, slotAt(day.Slots, time))
+		}
+		page.Rows = append(page.Rows, row)
+	}
+
+	writePage(w, http.StatusOK, "timetable.html", page)
+}
+
+// slotAt finds the slot starting at time, a busy one if the timetable has
+// none.
+func slotAt(slots []fft.Slot, time string) fft.Slot {
+	for _, slot := range slots {
+		if slot.Time == time {
+			return slot
+		}
+	}
+	return fft.Slot{Time: time}
+}
+
+func writePage(w http.ResponseWriter, status int, name string, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+
+	if err := pages.ExecuteTemplate(w, name, data); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	fft "github.com/thailekha/rooms-checker-go/api"
+)
+
+func TestSearchPage(t *testing.T) {
+	stubTimetables(t, newTimetable("IT101", fft.DefaultWeek), newTimetable("IT102", fft.DefaultWeek, "monday 9:15"))
+
+	cases := []struct {
+		name    string
+		target  string
+		want    int
+		body    []string
+		without []string
+	}{
+		{"serves the form", "/", 200, []string{`<form action="/" method="get">`}, []string{"<script", `type="password"`, "Free rooms"}},
+		{"fills the form in from the query", "/?weekday=tuesday&start=10:15&end=10:15&rooms=IT101", 200, []string{
+			`<option value="tuesday" selected>`,
+			`<option selected>10:15</option>`,
+			`value="IT101" checked>`,
+		}, nil},
+		{"renders the results", "/?weekday=monday&start=9:15&end=9:15&rooms=IT101&rooms=IT102", 200, []string{
+			"<h2>Free rooms</h2>",
+			`<tr><th><a href="/rooms/IT101">IT101</a></th><td class="free">free</td></tr>`,
+			`<tr><th><a href="/rooms/IT102">IT102</a></th><td class="busy">busy</td></tr>`,
+		}, nil},
+		{"says which rooms could not be checked", "/?weekday=monday&start=9:15&end=9:15&rooms=IT101&rooms=IT103", 200, []string{"Could not check: IT103"}, nil},
+		{"renders validation errors", "/?weekday=sunday&start=9:15&end=9:15", 400, []string{`<p class="error">`}, []string{"Free rooms"}},
+		{"renders upstream errors", "/?weekday=monday&start=9:15&end=9:15&rooms=IT103", 503, []string{`<p class="error">`}, []string{"Free rooms"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			start := time.Now()
+			w := serve(getSearchPage, "/", newRequest("GET", c.target, "", ""))
+
+			if w.Code != c.want {
+				t.Fatalf("got %d %s, want %d", w.Code, w.Body, c.want)
+			}
+			for _, want := range c.body {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("page lacks %s:\n%s", want, w.Body)
+				}
+			}
+			for _, without := range c.without {
+				if strings.Contains(w.Body.String(), without) {
+					t.Errorf("page has %s:\n%s", without, w.Body)
+				}
+			}
+			if searches, _ := fft.QueryHistory(fft.HistoryFilter{From: start}); len(searches) > 0 {
+				t.Errorf("recorded %+v", searches)
+			}
+		})
+	}
+}